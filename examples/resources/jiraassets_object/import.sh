@@ -0,0 +1,11 @@
+# Objects can be imported by their ID, using the provider workspace
+terraform import jiraassets_object.example_object 123
+
+# or by their object key
+terraform import jiraassets_object.example_object HW-42
+
+# or by their global ID, which includes the workspace they belong to
+terraform import jiraassets_object.example_object 1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d:123
+
+# or by their ID or object key qualified with the workspace they belong to
+terraform import jiraassets_object.example_object 1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d/123
@@ -8,6 +8,190 @@ resource "jiraassets_object" "example_object" {
     {
       attr_type_id = "102"
       attr_value   = "Description of my object"
+    },
+    {
+      attr_type_id = "103"
+      attr_values  = ["10.0.0.1", "10.0.0.2"]
+    }
+  ]
+}
+
+resource "jiraassets_object" "example_named_object" {
+  object_schema_key = "ITSM"
+  object_type_name  = "Laptop"
+  attribute_values = {
+    "Name"          = "My Laptop"
+    "Serial Number" = "ABC-123"
+  }
+}
+
+resource "jiraassets_object" "example_typed_object" {
+  type_id = "100"
+  attributes = [
+    {
+      attr_type_id = "101"
+      attr_value   = "My Server"
+    },
+    {
+      attr_type_id         = "104"
+      reference_object_key = "ITSM-42"
+    },
+    {
+      attr_type_id = "105"
+      user_email   = "owner@example.com"
+    },
+    {
+      attr_type_id = "106"
+      date_value   = "2024-01-31T00:00:00Z"
+    },
+    {
+      attr_type_id = "107"
+      status_name  = "In Service"
+    }
+  ]
+}
+
+resource "jiraassets_object" "example_adopted_object" {
+  object_schema_key = "ITSM"
+  object_type_name  = "Laptop"
+  adopt_existing    = true
+  match_on          = ["Serial Number"]
+  attribute_values = {
+    "Name"          = "My Laptop"
+    "Serial Number" = "ABC-456"
+  }
+}
+
+resource "jiraassets_object" "example_archived_object" {
+  type_id = "100"
+  attributes = [
+    {
+      attr_type_id = "101"
+      attr_value   = "My Retired Server"
+    }
+  ]
+  deletion_policy = "archive"
+  archive = {
+    status_attribute = "Status"
+    status           = "Retired"
+  }
+
+  timeouts {
+    create = "30m"
+    delete = "10m"
+  }
+}
+
+resource "jiraassets_object" "example_moved_object" {
+  type_id     = "110"
+  type_change = "move"
+  attribute_mapping = {
+    # Serial Number of the prior object type to Serial Number of object type 110
+    "102" = "112"
+  }
+  attributes = [
+    {
+      attr_type_id = "111"
+      attr_value   = "My Moved Object"
+    }
+  ]
+}
+
+variable "service_account_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "jiraassets_object" "example_service_account" {
+  type_id = "120"
+  attributes = [
+    {
+      attr_type_id = "121"
+      attr_value   = "svc-backup"
+    }
+  ]
+  sensitive_attributes = [
+    {
+      attr_type_id = "122"
+      attr_value   = var.service_account_password
+    }
+  ]
+}
+
+# Requires Terraform 1.11 or later. The values are never stored in state,
+# increment attr_values_wo_version to send changed values.
+resource "jiraassets_object" "example_write_only" {
+  type_id = "120"
+  attributes = [
+    {
+      attr_type_id = "121"
+      attr_value   = "svc-deploy"
+    }
+  ]
+  attr_values_wo = {
+    "122" = [var.service_account_password]
+  }
+  attr_values_wo_version = 1
+}
+
+resource "jiraassets_object" "example_server" {
+  type_id = "119"
+  attributes = [
+    {
+      attr_type_id = "1098"
+      attr_value   = "server-1"
+    }
+  ]
+
+  references {
+    attr_type_id = "1099"
+    object_ids   = [jiraassets_object.example_object.id]
+  }
+}
+
+# Servers referring to each other. Each one is created without its references,
+# which are set once the other server exists.
+resource "jiraassets_object" "example_primary" {
+  type_id              = "119"
+  two_phase_references = true
+  attributes = [
+    {
+      attr_type_id = "1098"
+      attr_value   = "server-primary"
+    }
+  ]
+
+  references {
+    attr_type_id = "1100"
+    aql          = "objectTypeId = 119 AND Name = \"server-standby\""
+  }
+}
+
+resource "jiraassets_object" "example_standby" {
+  type_id              = "119"
+  two_phase_references = true
+  attributes = [
+    {
+      attr_type_id = "1098"
+      attr_value   = "server-standby"
+    }
+  ]
+
+  references {
+    attr_type_id = "1100"
+    aql          = "objectTypeId = 119 AND Name = \"server-primary\""
+  }
+}
+
+# Fail the apply instead of overwriting attributes changed by someone else
+# since the last refresh.
+resource "jiraassets_object" "example_guarded" {
+  type_id            = "117"
+  conflict_detection = true
+  attributes = [
+    {
+      attr_type_id = "1087"
+      attr_value   = "Shared Phone"
     }
   ]
 }
@@ -0,0 +1,8 @@
+# Set the cost center of a laptop owned by another team, restoring the
+# previous cost center when the resource is destroyed.
+resource "jiraassets_object_attribute" "cost_center" {
+  object_key     = "ITSM-88"
+  attribute_name = "Cost Center"
+  values         = ["CC-4711"]
+  on_destroy     = "restore"
+}
@@ -0,0 +1,28 @@
+resource "jiraassets_objects" "phones" {
+  type_id = "117"
+  objects = {
+    "PH-0001" = {
+      attributes = {
+        "1087" = "Phone PH-0001"
+      }
+    }
+  }
+}
+
+# Delete the phones that are not managed by Terraform, at most 5 per apply.
+resource "jiraassets_object_scope" "phones" {
+  aql         = "objectTypeId = 117"
+  managed_ids = [for phone in jiraassets_objects.phones.objects : phone.id]
+  max_prune   = 5
+}
+
+# Archive the laptops that are not managed by Terraform.
+resource "jiraassets_object_scope" "laptops" {
+  aql          = "objectType = \"Laptops\""
+  managed_keys = ["ITSM-88", "ITSM-89"]
+  prune_action = "archive"
+  archive = {
+    status_attribute = "Status"
+    status           = "Retired"
+  }
+}
@@ -0,0 +1,20 @@
+locals {
+  phones = {
+    "PH-0001" = "1234657890"
+    "PH-0002" = "2345678901"
+    "PH-0003" = "3456789012"
+  }
+}
+
+resource "jiraassets_objects" "phones" {
+  type_id     = "117"
+  parallelism = 8
+  objects = {
+    for asset_tag, serial in local.phones : asset_tag => {
+      attributes = {
+        "1087" = "Phone ${asset_tag}"
+        "1090" = serial
+      }
+    }
+  }
+}
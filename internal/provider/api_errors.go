@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// wrapAPIError adds the HTTP status and response body returned by the Assets
+// API to err, since go-atlassian only reports a generic error per status code.
+// The logging transport of the provider redacts error bodies before
+// go-atlassian reads them, so the body holds no credentials or sensitive values.
+func wrapAPIError(response *models.ResponseScheme, err error) error {
+	if err == nil || response == nil {
+		return err
+	}
+
+	body := strings.TrimSpace(response.Bytes.String())
+	if body == "" {
+		return fmt.Errorf("%w (status %d)", err, response.Code)
+	}
+
+	return fmt.Errorf("%w (status %d): %s", err, response.Code, body)
+}
+
+// isNotFound reports whether the Assets API responded that the requested
+// entity does not exist. Resources remove themselves from state when Read
+// gets this response, and treat it as success in Delete.
+func isNotFound(response *models.ResponseScheme) bool {
+	return response != nil && response.Code == http.StatusNotFound
+}
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// This file holds Assets API calls, and response fields, that go-atlassian does not provide.
+
+// objectAttribute is an attribute of an object, including the referenced
+// object and user details that go-atlassian does not decode.
+type objectAttribute struct {
+	ID                    string                            `json:"id,omitempty"`
+	ObjectTypeAttributeId string                            `json:"objectTypeAttributeId,omitempty"`
+	ObjectTypeAttribute   *models.ObjectTypeAttributeScheme `json:"objectTypeAttribute,omitempty"`
+	ObjectAttributeValues []*objectAttributeValue           `json:"objectAttributeValues,omitempty"`
+}
+
+// objectAttributeValue is a single value of an object attribute.
+type objectAttributeValue struct {
+	Value            string                                           `json:"value,omitempty"`
+	DisplayValue     string                                           `json:"displayValue,omitempty"`
+	SearchValue      string                                           `json:"searchValue,omitempty"`
+	ReferencedObject *referencedObject                                `json:"referencedObject,omitempty"`
+	Group            *models.ObjectTypeAssetAttributeValueGroupScheme `json:"group,omitempty"`
+	Status           *models.ObjectTypeAssetAttributeStatusScheme     `json:"status,omitempty"`
+}
+
+// referencedObject is the object a reference attribute value points to.
+type referencedObject struct {
+	ID        string `json:"id,omitempty"`
+	ObjectKey string `json:"objectKey,omitempty"`
+	Label     string `json:"label,omitempty"`
+}
+
+// rawValue returns the value in the representation the API accepts on write:
+// the object ID for references, the status ID for statuses, the account ID for
+// users and the group name for groups.
+func (v *objectAttributeValue) rawValue() string {
+	switch {
+	case v.ReferencedObject != nil:
+		return v.ReferencedObject.ID
+	case v.Status != nil:
+		return v.Status.ID
+	case v.Group != nil && v.Group.Name != "":
+		return v.Group.Name
+	case v.Value != "":
+		return v.Value
+	default:
+		return v.SearchValue
+	}
+}
+
+// statusType is a status that status attributes of an object schema can be set to.
+type statusType struct {
+	ID             string `json:"id,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Category       int    `json:"category,omitempty"`
+	ObjectSchemaId string `json:"objectSchemaId,omitempty"`
+}
+
+// getObjectAttributes returns the attributes of an object with all their values.
+func getObjectAttributes(ctx context.Context, client *assets.Client, workspaceId, objectId string) ([]*objectAttribute, *models.ResponseScheme, error) {
+	endpoint := fmt.Sprintf("jsm/assets/workspace/%v/v1/object/%v/attributes", workspaceId, objectId)
+
+	req, err := client.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var attributes []*objectAttribute
+	response, err := client.Call(req, &attributes)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return attributes, response, nil
+}
+
+// objectSearchPage is a page of the objects matching an AQL query.
+type objectSearchPage struct {
+	StartAt    int               `json:"startAt,omitempty"`
+	MaxResults int               `json:"maxResults,omitempty"`
+	Total      int               `json:"total,omitempty"`
+	IsLast     bool              `json:"isLast,omitempty"`
+	Values     []*searchedObject `json:"values,omitempty"`
+}
+
+// searchedObject is an object matching an AQL query, with its attributes
+// decoded like those returned by getObjectAttributes.
+type searchedObject struct {
+	ID         string             `json:"id,omitempty"`
+	Label      string             `json:"label,omitempty"`
+	ObjectKey  string             `json:"objectKey,omitempty"`
+	Attributes []*objectAttribute `json:"attributes,omitempty"`
+}
+
+// searchObjects returns a page of the objects matching the AQL query, including
+// their attributes. Object.Filter of go-atlassian decodes attribute values
+// without the referenced objects, groups and statuses.
+func searchObjects(ctx context.Context, client *assets.Client, workspaceId, aql string, startAt, maxResults int) (*objectSearchPage, *models.ResponseScheme, error) {
+	params := url.Values{}
+	params.Add("startAt", strconv.Itoa(startAt))
+	params.Add("maxResults", strconv.Itoa(maxResults))
+	params.Add("includeAttributes", "true")
+
+	endpoint := fmt.Sprintf("jsm/assets/workspace/%v/v1/object/aql?%v", workspaceId, params.Encode())
+
+	req, err := client.NewRequest(ctx, http.MethodPost, endpoint, "", map[string]interface{}{"qlQuery": aql})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := &objectSearchPage{}
+	response, err := client.Call(req, page)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return page, response, nil
+}
+
+// objectSchemaPageSize is the number of object schemas requested per page.
+const objectSchemaPageSize = 50
+
+// listObjectSchemas returns a page of the object schemas of the workspace.
+// ObjectSchema.List of go-atlassian only returns the first page.
+func listObjectSchemas(ctx context.Context, client *assets.Client, workspaceId string, startAt, maxResults int) (*models.ObjectSchemaPageScheme, *models.ResponseScheme, error) {
+	params := url.Values{}
+	params.Add("startAt", strconv.Itoa(startAt))
+	params.Add("maxResults", strconv.Itoa(maxResults))
+
+	endpoint := fmt.Sprintf("jsm/assets/workspace/%v/v1/objectschema/list?%v", workspaceId, params.Encode())
+
+	req, err := client.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := &models.ObjectSchemaPageScheme{}
+	response, err := client.Call(req, page)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return page, response, nil
+}
+
+// listStatusTypes returns the global statuses and the statuses of the object schema.
+func listStatusTypes(ctx context.Context, client *assets.Client, workspaceId, objectSchemaId string) ([]*statusType, *models.ResponseScheme, error) {
+	params := url.Values{}
+	params.Add("objectSchemaId", objectSchemaId)
+
+	endpoint := fmt.Sprintf("jsm/assets/workspace/%v/v1/config/statustype?%v", workspaceId, params.Encode())
+
+	req, err := client.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var statuses []*statusType
+	response, err := client.Call(req, &statuses)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return statuses, response, nil
+}
+
+// objectUpdatePayload is the payload of an object update. Unlike
+// models.ObjectPayloadScheme it sends attributes without values, which is how
+// the API clears an attribute.
+type objectUpdatePayload struct {
+	ObjectTypeID string                   `json:"objectTypeId,omitempty"`
+	AvatarUUID   string                   `json:"avatarUUID,omitempty"`
+	HasAvatar    bool                     `json:"hasAvatar,omitempty"`
+	Attributes   []*objectUpdateAttribute `json:"attributes,omitempty"`
+}
+
+// objectUpdateAttribute is an attribute of an object update, an empty list of
+// values clears the attribute.
+type objectUpdateAttribute struct {
+	ObjectTypeAttributeID string                                      `json:"objectTypeAttributeId"`
+	ObjectAttributeValues []*models.ObjectPayloadAttributeValueScheme `json:"objectAttributeValues"`
+}
+
+// updateObject updates an object, clearing the attributes sent without values.
+func updateObject(ctx context.Context, client *assets.Client, workspaceId, objectId string, payload *objectUpdatePayload) (*models.ObjectScheme, *models.ResponseScheme, error) {
+	endpoint := fmt.Sprintf("jsm/assets/workspace/%v/v1/object/%v", workspaceId, objectId)
+
+	req, err := client.NewRequest(ctx, http.MethodPut, endpoint, "", payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	object := new(models.ObjectScheme)
+	response, err := client.Call(req, object)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return object, response, nil
+}
@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Values of attribute_management.
+const (
+	// attributeManagementPartial only manages the configured attributes and
+	// the attributes removed from the configuration.
+	attributeManagementPartial = "partial"
+
+	// attributeManagementAuthoritative manages every editable attribute of
+	// the object, clearing those that are not configured.
+	attributeManagementAuthoritative = "authoritative"
+)
+
+// managedAttributeIds returns the IDs of the attributes configured on the
+// object, whether addressed by ID, by name or as references.
+func (m objectResourceModel) managedAttributeIds(ctx context.Context) (map[string]bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	ids := map[string]bool{}
+	for _, attr := range m.Attributes {
+		ids[attr.AttrTypeId.ValueString()] = true
+	}
+
+	for id := range m.sensitiveAttributeIds() {
+		ids[id] = true
+	}
+
+	for id := range m.referenceAttributeIds() {
+		ids[id] = true
+	}
+
+	if m.AttributeIds.IsNull() || m.AttributeIds.IsUnknown() {
+		return ids, diags
+	}
+
+	var attributeIds map[string]string
+	diags.Append(m.AttributeIds.ElementsAs(ctx, &attributeIds, false)...)
+	for _, id := range attributeIds {
+		ids[id] = true
+	}
+
+	return ids, diags
+}
+
+// isAuthoritative reports whether the object manages all of its attributes.
+func (m objectResourceModel) isAuthoritative() bool {
+	return m.AttributeManagement.ValueString() == attributeManagementAuthoritative
+}
+
+// removedAttributeIds returns the IDs of the attributes managed in the prior
+// state that are no longer configured, sorted for a stable payload.
+func removedAttributeIds(prior, planned map[string]bool) []string {
+	var removed []string
+	for id := range prior {
+		if !planned[id] {
+			removed = append(removed, id)
+		}
+	}
+
+	sort.Strings(removed)
+
+	return removed
+}
+
+// isClearable reports whether Terraform may clear the attribute when it is
+// not configured. System and read only attributes are maintained by Assets,
+// mandatory attributes cannot be cleared.
+func isClearable(definition *models.ObjectTypeAttributeScheme) bool {
+	return definition != nil && definition.Editable && !definition.System && definition.MinimumCardinality == 0
+}
+
+// unmanagedAttributeIds returns the IDs of the clearable attributes the object
+// has values for that are not configured, sorted for a stable payload.
+func unmanagedAttributeIds(definitions []*models.ObjectTypeAttributeScheme, attrs []*objectAttribute, planned map[string]bool) []string {
+	var unmanaged []string
+	for _, a := range attrs {
+		if planned[a.ObjectTypeAttributeId] || len(a.ObjectAttributeValues) == 0 {
+			continue
+		}
+
+		if isClearable(findAttributeById(definitions, a.ObjectTypeAttributeId)) {
+			unmanaged = append(unmanaged, a.ObjectTypeAttributeId)
+		}
+	}
+
+	sort.Strings(unmanaged)
+
+	return unmanaged
+}
+
+// unmanagedAttributes returns the clearable attributes the object has values
+// for that are not configured, as attributes of the attributes set. Adding
+// them to state shows them as drift that the next apply clears.
+func unmanagedAttributes(ctx context.Context, definitions []*models.ObjectTypeAttributeScheme, attrs []*objectAttribute, managed map[string]bool) ([]objectAttrResourceModel, diag.Diagnostics) {
+	return attributeElements(ctx, attrs, unmanagedAttributeIds(definitions, attrs, managed))
+}
+
+// importedAttributes returns every editable, non-system attribute the object
+// has values for, as attributes of the attributes set.
+func importedAttributes(ctx context.Context, definitions []*models.ObjectTypeAttributeScheme, attrs []*objectAttribute) ([]objectAttrResourceModel, diag.Diagnostics) {
+	var ids []string
+	for _, a := range attrs {
+		definition := findAttributeById(definitions, a.ObjectTypeAttributeId)
+		if definition == nil || !definition.Editable || definition.System || len(a.ObjectAttributeValues) == 0 {
+			continue
+		}
+
+		ids = append(ids, a.ObjectTypeAttributeId)
+	}
+
+	sort.Strings(ids)
+
+	return attributeElements(ctx, attrs, ids)
+}
+
+// attributeElements returns the attributes with the given IDs as attributes of
+// the attributes set, using attr_value for a single value and attr_values for
+// several values.
+func attributeElements(ctx context.Context, attrs []*objectAttribute, ids []string) ([]objectAttrResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var elements []objectAttrResourceModel
+	for _, id := range ids {
+		attr := objectAttrResourceModel{
+			AttrTypeId: types.StringValue(id),
+		}
+		attr.setNullValues()
+
+		values := objectAttributeValues(attrs, id)
+		if len(values) == 1 {
+			attr.AttrValue = types.StringValue(values[0])
+		} else {
+			var d diag.Diagnostics
+			attr.AttrValues, d = types.ListValueFrom(ctx, types.StringType, values)
+			diags.Append(d...)
+		}
+
+		elements = append(elements, attr)
+	}
+
+	return elements, diags
+}
+
+// allAttributesValue returns the values of every attribute of the object,
+// including system attributes, keyed by attribute name. Sensitive attributes
+// are left out.
+func allAttributesValue(ctx context.Context, definitions []*models.ObjectTypeAttributeScheme, attrs []*objectAttribute, sensitive map[string]bool) (types.Map, diag.Diagnostics) {
+	all := map[string][]string{}
+	for _, a := range attrs {
+		if sensitive[a.ObjectTypeAttributeId] {
+			continue
+		}
+
+		name := a.ObjectTypeAttributeId
+		if definition := findAttributeById(definitions, a.ObjectTypeAttributeId); definition != nil {
+			name = definition.Name
+		} else if a.ObjectTypeAttribute != nil && a.ObjectTypeAttribute.Name != "" {
+			name = a.ObjectTypeAttribute.Name
+		}
+
+		values := []string{}
+		for _, v := range a.ObjectAttributeValues {
+			values = append(values, v.rawValue())
+		}
+
+		all[name] = append(all[name], values...)
+	}
+
+	return types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, all)
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+func TestRemovedAttributeIds(t *testing.T) {
+	prior := map[string]bool{"1": true, "2": true, "3": true}
+	planned := map[string]bool{"2": true, "4": true}
+
+	if got := removedAttributeIds(prior, planned); !reflect.DeepEqual(got, []string{"1", "3"}) {
+		t.Errorf("expected [1 3], got %v", got)
+	}
+}
+
+func TestUnmanagedAttributeIds(t *testing.T) {
+	definitions := []*models.ObjectTypeAttributeScheme{
+		{ID: "1", Name: "Key", System: true},
+		{ID: "2", Name: "Name", Editable: true, MinimumCardinality: 1},
+		{ID: "3", Name: "Serial Number", Editable: true},
+		{ID: "4", Name: "Notes", Editable: true},
+		{ID: "5", Name: "Owner", Editable: true},
+	}
+
+	attrs := []*objectAttribute{
+		{ObjectTypeAttributeId: "1", ObjectAttributeValues: []*objectAttributeValue{{Value: "ITSM-1"}}},
+		{ObjectTypeAttributeId: "2", ObjectAttributeValues: []*objectAttributeValue{{Value: "My Phone"}}},
+		{ObjectTypeAttributeId: "3", ObjectAttributeValues: []*objectAttributeValue{{Value: "ABC-123"}}},
+		{ObjectTypeAttributeId: "4", ObjectAttributeValues: []*objectAttributeValue{{Value: "Managed"}}},
+		{ObjectTypeAttributeId: "5"},
+	}
+
+	got := unmanagedAttributeIds(definitions, attrs, map[string]bool{"4": true})
+	if !reflect.DeepEqual(got, []string{"3"}) {
+		t.Errorf("expected [3], got %v", got)
+	}
+}
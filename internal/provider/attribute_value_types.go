@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = dateValueType{}
+	_ basetypes.StringValuableWithSemanticEquals = dateValue{}
+)
+
+// dateValueType is the type of date_value. Timestamps of the same instant are equal.
+type dateValueType struct {
+	basetypes.StringType
+}
+
+func (t dateValueType) Equal(o attr.Type) bool {
+	other, ok := o.(dateValueType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t dateValueType) String() string {
+	return "dateValueType"
+}
+
+func (t dateValueType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return dateValue{StringValue: in}, nil
+}
+
+func (t dateValueType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return dateValue{StringValue: stringValue}, nil
+}
+
+func (t dateValueType) ValueType(_ context.Context) attr.Value {
+	return dateValue{}
+}
+
+// dateValue is an RFC3339 timestamp of a Date or DateTime attribute.
+type dateValue struct {
+	basetypes.StringValue
+}
+
+func (v dateValue) Equal(o attr.Value) bool {
+	other, ok := o.(dateValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v dateValue) Type(_ context.Context) attr.Type {
+	return dateValueType{}
+}
+
+// StringSemanticEquals reports whether both timestamps are the same instant.
+func (v dateValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(dateValue)
+	if !ok {
+		return false, nil
+	}
+
+	return equalDates(v.ValueString(), newValue.ValueString()), nil
+}
+
+// newDateValue returns a known date value.
+func newDateValue(value string) dateValue {
+	return dateValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// nullDateValue returns a null date value.
+func nullDateValue() dateValue {
+	return dateValue{StringValue: basetypes.NewStringNull()}
+}
+
+// equalAttributeValues reports whether two representations of a value of the
+// attribute are the same value, comparing them according to the data type of
+// the attribute. Without a definition only equal strings are the same value.
+func equalAttributeValues(definition *models.ObjectTypeAttributeScheme, a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	if definition == nil {
+		return false
+	}
+
+	switch {
+	case isDefaultType(definition, defaultTypeBoolean):
+		return equalBooleans(a, b)
+	case isDefaultType(definition, defaultTypeInteger, defaultTypeDouble):
+		return equalNumbers(a, b)
+	case isDefaultType(definition, defaultTypeDate, defaultTypeDateTime):
+		return equalDates(a, b)
+	default:
+		return false
+	}
+}
+
+// equalBooleans reports whether both values are the same boolean, ignoring case.
+func equalBooleans(a, b string) bool {
+	x, errA := strconv.ParseBool(strings.ToLower(a))
+	y, errB := strconv.ParseBool(strings.ToLower(b))
+
+	return errA == nil && errB == nil && x == y
+}
+
+// equalNumbers reports whether both values are the same number, ignoring
+// trailing zeros and exponent notation.
+func equalNumbers(a, b string) bool {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+
+	return errA == nil && errB == nil && x == y
+}
+
+// equalDates reports whether both values are the same instant, in any of the
+// formats the API returns dates in.
+func equalDates(a, b string) bool {
+	x, errA := time.Parse(time.RFC3339, normalizeDate(a))
+	y, errB := time.Parse(time.RFC3339, normalizeDate(b))
+
+	return errA == nil && errB == nil && x.Equal(y)
+}
+
+// matchesAttributeValue reports whether the configured value refers to the
+// value returned by the API. References may be configured by object ID, key or
+// label, and statuses by ID or name.
+func matchesAttributeValue(definition *models.ObjectTypeAttributeScheme, configured string, value *objectAttributeValue) bool {
+	if configured == value.rawValue() {
+		return true
+	}
+
+	if value.ReferencedObject != nil && (configured == value.ReferencedObject.ObjectKey || configured == value.ReferencedObject.Label) {
+		return true
+	}
+
+	if value.Status != nil && configured == value.Status.Name {
+		return true
+	}
+
+	return equalAttributeValues(definition, configured, value.Value)
+}
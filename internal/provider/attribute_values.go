@@ -0,0 +1,341 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Attribute types, as returned in the type of an object type attribute.
+const (
+	attributeTypeDefault   = 0
+	attributeTypeReference = 1
+	attributeTypeUser      = 2
+	attributeTypeGroup     = 4
+	attributeTypeStatus    = 7
+)
+
+// Data types of default attributes, as returned in the default type of an object type attribute.
+const (
+	defaultTypeInteger  = 1
+	defaultTypeBoolean  = 2
+	defaultTypeDouble   = 3
+	defaultTypeDate     = 4
+	defaultTypeDateTime = 6
+	defaultTypeSelect   = 10
+)
+
+// dateLayout is the format of values of Date attributes.
+const dateLayout = "2006-01-02"
+
+// attributeValueFields are the attributes of the attributes nested object that
+// hold a value. Exactly one of them must be set.
+var attributeValueFields = []string{
+	"attr_value",
+	"attr_values",
+	"reference_object_key",
+	"user_email",
+	"account_id",
+	"group_name",
+	"date_value",
+	"bool_value",
+	"number_value",
+	"select_value",
+	"status_name",
+}
+
+// valueField returns the name of the field that holds the value of the attribute.
+func (m objectAttrResourceModel) valueField() string {
+	switch {
+	case !m.AttrValues.IsNull():
+		return "attr_values"
+	case !m.ReferenceObjectKey.IsNull():
+		return "reference_object_key"
+	case !m.UserEmail.IsNull():
+		return "user_email"
+	case !m.AccountId.IsNull():
+		return "account_id"
+	case !m.GroupName.IsNull():
+		return "group_name"
+	case !m.DateValue.IsNull():
+		return "date_value"
+	case !m.BoolValue.IsNull():
+		return "bool_value"
+	case !m.NumberValue.IsNull():
+		return "number_value"
+	case !m.SelectValue.IsNull():
+		return "select_value"
+	case !m.StatusName.IsNull():
+		return "status_name"
+	default:
+		return "attr_value"
+	}
+}
+
+// checkValueType returns an error when the value field of the attribute cannot
+// hold a value of the attribute definition's type.
+func (m objectAttrResourceModel) checkValueType(definition *models.ObjectTypeAttributeScheme) error {
+	field := m.valueField()
+
+	var valid bool
+	switch field {
+	case "attr_value", "attr_values":
+		valid = true
+	case "reference_object_key":
+		valid = definition.Type == attributeTypeReference
+	case "user_email", "account_id":
+		valid = definition.Type == attributeTypeUser
+	case "group_name":
+		valid = definition.Type == attributeTypeGroup
+	case "status_name":
+		valid = definition.Type == attributeTypeStatus
+	case "date_value":
+		valid = isDefaultType(definition, defaultTypeDate, defaultTypeDateTime)
+	case "bool_value":
+		valid = isDefaultType(definition, defaultTypeBoolean)
+	case "number_value":
+		valid = isDefaultType(definition, defaultTypeInteger, defaultTypeDouble)
+	case "select_value":
+		valid = isDefaultType(definition, defaultTypeSelect)
+	}
+
+	if !valid {
+		return fmt.Errorf("%s cannot be used for attribute %q, use a value field matching the attribute type", field, definition.Name)
+	}
+
+	if field == "number_value" && isDefaultType(definition, defaultTypeInteger) && !m.NumberValue.IsUnknown() {
+		if v := m.NumberValue.ValueFloat64(); v != math.Trunc(v) {
+			return fmt.Errorf("attribute %q is an integer attribute, got %v", definition.Name, v)
+		}
+	}
+
+	return nil
+}
+
+// isDefaultType reports whether the attribute is a default attribute of one of the data types.
+func isDefaultType(definition *models.ObjectTypeAttributeScheme, dataTypes ...int) bool {
+	if definition.Type != attributeTypeDefault || definition.DefaultType == nil {
+		return false
+	}
+
+	for _, t := range dataTypes {
+		if definition.DefaultType.ID == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// attributeValueResolver converts typed attribute values to and from the
+// representation used by the Assets API, looking up IDs through the cache.
+type attributeValueResolver struct {
+	metadata     *metadataCache
+	workspaceId  string
+	objectTypeId string
+	definitions  []*models.ObjectTypeAttributeScheme
+}
+
+// toAPI returns the values of the attribute as sent to the API.
+func (v attributeValueResolver) toAPI(ctx context.Context, definition *models.ObjectTypeAttributeScheme, m objectAttrResourceModel) ([]string, error) {
+	switch m.valueField() {
+	case "attr_values":
+		var values []string
+		if diags := m.AttrValues.ElementsAs(ctx, &values, false); diags.HasError() {
+			return nil, fmt.Errorf("reading attr_values of attribute %s", m.AttrTypeId.ValueString())
+		}
+		return values, nil
+	case "reference_object_key":
+		objectId, err := v.metadata.ObjectIdByKey(ctx, v.workspaceId, m.ReferenceObjectKey.ValueString())
+		return []string{objectId}, err
+	case "user_email":
+		accountId, err := v.metadata.AccountIdByEmail(ctx, m.UserEmail.ValueString())
+		return []string{accountId}, err
+	case "account_id":
+		return []string{m.AccountId.ValueString()}, nil
+	case "group_name":
+		return []string{m.GroupName.ValueString()}, nil
+	case "date_value":
+		t, err := time.Parse(time.RFC3339, m.DateValue.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("date_value must be an RFC3339 timestamp: %w", err)
+		}
+		if definition != nil && isDefaultType(definition, defaultTypeDate) {
+			return []string{t.Format(dateLayout)}, nil
+		}
+		return []string{t.UTC().Format(time.RFC3339)}, nil
+	case "bool_value":
+		return []string{strconv.FormatBool(m.BoolValue.ValueBool())}, nil
+	case "number_value":
+		return []string{strconv.FormatFloat(m.NumberValue.ValueFloat64(), 'f', -1, 64)}, nil
+	case "select_value":
+		return []string{m.SelectValue.ValueString()}, nil
+	case "status_name":
+		statusId, err := v.statusId(ctx, m.StatusName.ValueString())
+		return []string{statusId}, err
+	default:
+		return []string{m.AttrValue.ValueString()}, nil
+	}
+}
+
+// statusId returns the ID of the status with the given name in the schema of the object type.
+func (v attributeValueResolver) statusId(ctx context.Context, name string) (string, error) {
+	objectType, err := v.metadata.ObjectType(ctx, v.workspaceId, v.objectTypeId)
+	if err != nil {
+		return "", err
+	}
+
+	statuses, err := v.metadata.StatusTypes(ctx, v.workspaceId, objectType.ObjectSchemaId)
+	if err != nil {
+		return "", err
+	}
+
+	for _, s := range statuses {
+		if s.Name == name {
+			return s.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("no status named %q exists in object schema %s", name, objectType.ObjectSchemaId)
+}
+
+// fromAPI returns the attribute refreshed with the values returned by the API,
+// in the same value field as the prior attribute. Values are kept in their
+// prior representation when they are the same value for the attribute's data
+// type. It returns false when the object has no values for the attribute.
+func (v attributeValueResolver) fromAPI(ctx context.Context, prior objectAttrResourceModel, values []*objectAttributeValue) (objectAttrResourceModel, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	field := prior.valueField()
+
+	refreshed := objectAttrResourceModel{
+		AttrTypeId: prior.AttrTypeId,
+	}
+	refreshed.setNullValues()
+
+	// an attribute without values no longer exists on the object, except for
+	// an empty list of values which is kept as configured
+	if len(values) == 0 {
+		if field == "attr_values" && len(prior.AttrValues.Elements()) == 0 {
+			return prior, true, nil
+		}
+		return refreshed, false, nil
+	}
+
+	value := values[0]
+	definition := findAttributeById(v.definitions, prior.AttrTypeId.ValueString())
+
+	switch field {
+	case "attr_values":
+		priorValues, d := prior.values(ctx)
+		diags.Append(d...)
+
+		refreshed.AttrValues, d = types.ListValueFrom(ctx, types.StringType, keepValueOrder(priorValues, matchPriorValues(definition, priorValues, values)))
+		diags.Append(d...)
+	case "reference_object_key":
+		objectKey := value.SearchValue
+		if value.ReferencedObject != nil {
+			objectKey = value.ReferencedObject.ObjectKey
+		}
+		refreshed.ReferenceObjectKey = types.StringValue(objectKey)
+	case "user_email":
+		// the API only returns account IDs, keep the email address while it resolves to the same account
+		refreshed.UserEmail = types.StringValue(value.rawValue())
+		if accountId, err := v.metadata.AccountIdByEmail(ctx, prior.UserEmail.ValueString()); err == nil && accountId == value.rawValue() {
+			refreshed.UserEmail = prior.UserEmail
+		}
+	case "account_id":
+		refreshed.AccountId = types.StringValue(value.rawValue())
+	case "group_name":
+		refreshed.GroupName = types.StringValue(value.rawValue())
+	case "date_value":
+		refreshed.DateValue = newDateValue(normalizeDate(value.Value))
+	case "bool_value":
+		refreshed.BoolValue = types.BoolValue(strings.EqualFold(value.Value, "true"))
+	case "number_value":
+		number, err := strconv.ParseFloat(value.Value, 64)
+		if err != nil {
+			diags.AddError(
+				"Unexpected Attribute Value",
+				fmt.Sprintf("Attribute %s has the non-numeric value %q.", prior.AttrTypeId.ValueString(), value.Value),
+			)
+			return refreshed, false, diags
+		}
+		refreshed.NumberValue = types.Float64Value(number)
+	case "select_value":
+		refreshed.SelectValue = types.StringValue(value.Value)
+	case "status_name":
+		name := value.DisplayValue
+		if value.Status != nil {
+			name = value.Status.Name
+		}
+		refreshed.StatusName = types.StringValue(name)
+	default:
+		refreshed.AttrValue = types.StringValue(value.rawValue())
+		if matchesAttributeValue(definition, prior.AttrValue.ValueString(), value) {
+			refreshed.AttrValue = prior.AttrValue
+		}
+	}
+
+	return refreshed, true, diags
+}
+
+// matchPriorValues returns the raw values returned by the API, replacing each
+// value that matches a prior value by that prior value.
+func matchPriorValues(definition *models.ObjectTypeAttributeScheme, prior []string, values []*objectAttributeValue) []string {
+	used := make([]bool, len(prior))
+
+	var matched []string
+	for _, value := range values {
+		raw := value.rawValue()
+		for i, p := range prior {
+			if !used[i] && matchesAttributeValue(definition, p, value) {
+				used[i] = true
+				raw = p
+				break
+			}
+		}
+
+		matched = append(matched, raw)
+	}
+
+	return matched
+}
+
+// setNullValues sets every value field of the attribute to null.
+func (m *objectAttrResourceModel) setNullValues() {
+	m.AttrValue = types.StringNull()
+	m.AttrValues = types.ListNull(types.StringType)
+	m.ReferenceObjectKey = types.StringNull()
+	m.UserEmail = types.StringNull()
+	m.AccountId = types.StringNull()
+	m.GroupName = types.StringNull()
+	m.DateValue = nullDateValue()
+	m.BoolValue = types.BoolNull()
+	m.NumberValue = types.Float64Null()
+	m.SelectValue = types.StringNull()
+	m.StatusName = types.StringNull()
+}
+
+// normalizeDate returns a Date or DateTime value returned by the API as an
+// RFC3339 timestamp, or the value unchanged when it cannot be parsed.
+func normalizeDate(value string) string {
+	if t, err := time.Parse(dateLayout, value); err == nil {
+		return t.Format(time.RFC3339)
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.000Z0700", "2006-01-02T15:04:05Z0700"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeDate(t *testing.T) {
+	tests := map[string]string{
+		"2024-01-31":                   "2024-01-31T00:00:00Z",
+		"2024-01-31T10:00:00.000+0100": "2024-01-31T09:00:00Z",
+		"2024-01-31T09:00:00Z":         "2024-01-31T09:00:00Z",
+		"not a date":                   "not a date",
+	}
+
+	for value, expected := range tests {
+		if got := normalizeDate(value); got != expected {
+			t.Errorf("normalizeDate(%q) = %q, expected %q", value, got, expected)
+		}
+	}
+}
+
+func TestCheckValueType(t *testing.T) {
+	integer := &models.ObjectTypeAttributeScheme{
+		Name:        "Cores",
+		DefaultType: &models.ObjectTypeAssetAttributeDefaultTypeScheme{ID: defaultTypeInteger},
+	}
+	reference := &models.ObjectTypeAttributeScheme{
+		Name: "Owner",
+		Type: attributeTypeReference,
+	}
+
+	attr := objectAttrResourceModel{}
+	attr.setNullValues()
+
+	number := attr
+	number.NumberValue = types.Float64Value(4)
+	if err := number.checkValueType(integer); err != nil {
+		t.Errorf("unexpected error for an integer value: %s", err)
+	}
+
+	number.NumberValue = types.Float64Value(4.5)
+	if err := number.checkValueType(integer); err == nil {
+		t.Error("expected an error for a fractional value of an integer attribute")
+	}
+
+	if err := number.checkValueType(reference); err == nil {
+		t.Error("expected an error for a number value of a reference attribute")
+	}
+
+	key := attr
+	key.ReferenceObjectKey = types.StringValue("ITSM-42")
+	if err := key.checkValueType(reference); err != nil {
+		t.Errorf("unexpected error for a reference value: %s", err)
+	}
+}
+
+func TestMatchesAttributeValue(t *testing.T) {
+	double := &models.ObjectTypeAttributeScheme{
+		DefaultType: &models.ObjectTypeAssetAttributeDefaultTypeScheme{ID: defaultTypeDouble},
+	}
+	boolean := &models.ObjectTypeAttributeScheme{
+		DefaultType: &models.ObjectTypeAssetAttributeDefaultTypeScheme{ID: defaultTypeBoolean},
+	}
+	text := &models.ObjectTypeAttributeScheme{}
+
+	reference := &objectAttributeValue{
+		ReferencedObject: &referencedObject{ID: "42", ObjectKey: "ITSM-42", Label: "My Server"},
+	}
+
+	tests := []struct {
+		definition *models.ObjectTypeAttributeScheme
+		configured string
+		value      *objectAttributeValue
+		expected   bool
+	}{
+		{double, "1.50", &objectAttributeValue{Value: "1.5"}, true},
+		{double, "1.5", &objectAttributeValue{Value: "1.6"}, false},
+		{boolean, "True", &objectAttributeValue{Value: "true"}, true},
+		{text, "True", &objectAttributeValue{Value: "true"}, false},
+		{text, "1", &objectAttributeValue{Value: "1.0"}, false},
+		{nil, "1", &objectAttributeValue{Value: "true"}, false},
+		{nil, "0", &objectAttributeValue{Value: "f"}, false},
+		{nil, "2024-01-31T00:00:00Z", &objectAttributeValue{Value: "2024-01-31"}, false},
+		{nil, "ITSM-42", reference, true},
+		{nil, "My Server", reference, true},
+		{nil, "ITSM-43", reference, false},
+	}
+
+	for _, test := range tests {
+		if got := matchesAttributeValue(test.definition, test.configured, test.value); got != test.expected {
+			t.Errorf("matchesAttributeValue(%q, %+v) = %v, expected %v", test.configured, test.value, got, test.expected)
+		}
+	}
+}
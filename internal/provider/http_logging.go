@@ -0,0 +1,393 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redactedValue replaces credentials and sensitive values in logs and HAR files.
+const redactedValue = "REDACTED"
+
+// redactedHeaders are never written to logs or HAR files in clear text.
+var redactedHeaders = map[string]struct{}{
+	"Authorization":       {},
+	"Proxy-Authorization": {},
+	"Cookie":              {},
+	"Set-Cookie":          {},
+}
+
+// redactor masks registered secret values wherever they appear in logged text.
+// It is shared by the provider and its resources, so resources can register
+// sensitive attribute values before they are sent to the API.
+type redactor struct {
+	mu     sync.RWMutex
+	values map[string]struct{}
+}
+
+func newRedactor() *redactor {
+	return &redactor{values: map[string]struct{}{}}
+}
+
+// Add registers values that must never appear in logs. Empty values are ignored.
+func (r *redactor) Add(values ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, v := range values {
+		if v != "" {
+			r.values[v] = struct{}{}
+		}
+	}
+}
+
+// Redact returns s with every registered value replaced.
+func (r *redactor) Redact(s string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// replace longer values first so a secret containing another secret is fully masked
+	values := make([]string, 0, len(r.values))
+	for v := range r.values {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+
+	for _, v := range values {
+		s = strings.ReplaceAll(s, v, redactedValue)
+	}
+
+	return s
+}
+
+// Headers returns a copy of the headers with credentials and registered values masked.
+func (r *redactor) Headers(headers http.Header) http.Header {
+	redacted := make(http.Header, len(headers))
+	for name, values := range headers {
+		for _, v := range values {
+			if _, ok := redactedHeaders[http.CanonicalHeaderKey(name)]; ok {
+				v = redactedValue
+			}
+			redacted.Add(name, r.Redact(v))
+		}
+	}
+
+	return redacted
+}
+
+// loggingTransport is an http.RoundTripper that logs every Assets API exchange
+// at TRACE level and optionally records it to a HAR file.
+type loggingTransport struct {
+	transport http.RoundTripper
+	redactor  *redactor
+	har       *harRecorder
+}
+
+func newLoggingTransport(transport http.RoundTripper, redactor *redactor, har *harRecorder) *loggingTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &loggingTransport{
+		transport: transport,
+		redactor:  redactor,
+		har:       har,
+	}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	requestBody, err := drainBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	duration := time.Since(started)
+
+	fields := map[string]interface{}{
+		"method":       req.Method,
+		"url":          t.redactor.Redact(req.URL.String()),
+		"duration_ms":  duration.Milliseconds(),
+		"request_body": t.redactor.Redact(string(requestBody)),
+	}
+
+	if err != nil {
+		fields["error"] = t.redactor.Redact(err.Error())
+		tflog.Trace(ctx, "Assets API request failed", fields)
+		return resp, err
+	}
+
+	responseBody, err := drainBody(&resp.Body)
+	if err != nil {
+		// the body is only replaced when it was read, close the connection
+		resp.Body.Close()
+		return nil, err
+	}
+
+	fields["status_code"] = resp.StatusCode
+	fields["response_body"] = t.redactor.Redact(string(responseBody))
+	tflog.Trace(ctx, "Assets API request", fields)
+
+	if t.har != nil {
+		err := t.har.Record(t.harEntry(req, requestBody, resp, responseBody, started, duration))
+		if err != nil {
+			tflog.Warn(ctx, "Unable to write HTTP log file", map[string]interface{}{
+				"path":  t.har.path,
+				"error": err.Error(),
+			})
+		}
+	}
+
+	// error bodies end up in diagnostics and the Terraform log through
+	// wrapAPIError and may echo the request, so they are redacted like the
+	// HTTP logs. Successful bodies are parsed and must stay intact.
+	if resp.StatusCode >= http.StatusBadRequest && len(responseBody) > 0 {
+		redacted := []byte(t.redactor.Redact(string(responseBody)))
+		resp.Body = io.NopCloser(bytes.NewReader(redacted))
+		resp.ContentLength = int64(len(redacted))
+	}
+
+	return resp, nil
+}
+
+// drainBody reads the body fully and replaces it with an in-memory copy so it
+// can still be consumed by the caller.
+func drainBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	content, err := io.ReadAll(*body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := (*body).Close(); err != nil {
+		return nil, err
+	}
+
+	*body = io.NopCloser(bytes.NewReader(content))
+
+	return content, nil
+}
+
+func (t *loggingTransport) harEntry(req *http.Request, requestBody []byte, resp *http.Response, responseBody []byte, started time.Time, duration time.Duration) harEntry {
+	entry := harEntry{
+		StartedDateTime: started.Format(time.RFC3339Nano),
+		Time:            duration.Milliseconds(),
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         t.redactor.Redact(req.URL.String()),
+			HTTPVersion: req.Proto,
+			Headers:     harHeaders(t.redactor.Headers(req.Header)),
+			QueryString: []harNameValue{},
+			Cookies:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(requestBody),
+		},
+		Response: harResponse{
+			Status:      resp.StatusCode,
+			StatusText:  http.StatusText(resp.StatusCode),
+			HTTPVersion: resp.Proto,
+			Headers:     harHeaders(t.redactor.Headers(resp.Header)),
+			Cookies:     []harNameValue{},
+			Content: harContent{
+				Size:     len(responseBody),
+				MimeType: resp.Header.Get("Content-Type"),
+				Text:     t.redactor.Redact(string(responseBody)),
+			},
+			RedirectURL: resp.Header.Get("Location"),
+			HeadersSize: -1,
+			BodySize:    len(responseBody),
+		},
+		Cache: struct{}{},
+		Timings: harTimings{
+			Wait: duration.Milliseconds(),
+		},
+	}
+
+	for name, values := range req.URL.Query() {
+		for _, v := range values {
+			entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{
+				Name:  name,
+				Value: t.redactor.Redact(v),
+			})
+		}
+	}
+
+	if len(requestBody) > 0 {
+		entry.Request.PostData = &harPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     t.redactor.Redact(string(requestBody)),
+		}
+	}
+
+	return entry
+}
+
+func harHeaders(headers http.Header) []harNameValue {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := []harNameValue{}
+	for _, name := range names {
+		for _, v := range headers[name] {
+			result = append(result, harNameValue{Name: name, Value: v})
+		}
+	}
+
+	return result
+}
+
+// harClosing ends the HAR document after the last entry.
+const harClosing = "\n]}}\n"
+
+// harRecorder writes HTTP exchanges to a HAR 1.2 file. Every entry is appended
+// in place of the closing brackets, which are written again after it, so the
+// file is a complete HAR document whenever Terraform stops the provider process.
+//
+// Terraform starts a new provider process for every command, such as plan and
+// then apply. Each process appends to the file left by the previous one rather
+// than overwriting it; delete the file to start a new recording. Provider
+// configurations running at the same time must not share a file.
+type harRecorder struct {
+	mu      sync.Mutex
+	path    string
+	creator harCreator
+}
+
+func newHarRecorder(path, version string) *harRecorder {
+	return &harRecorder{
+		path: path,
+		creator: harCreator{
+			Name:    "terraform-provider-jiraassets",
+			Version: version,
+		},
+	}
+}
+
+// Record appends an entry to the HAR file, creating the file if needed. A file
+// that does not end like a HAR document written by the provider is left as is.
+func (h *harRecorder) Record(entry harEntry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(h.path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	offset := info.Size() - int64(len(harClosing))
+	if info.Size() == 0 {
+		header, err := json.Marshal(map[string]harLog{"log": {Version: "1.2", Creator: h.creator, Entries: []harEntry{}}})
+		if err != nil {
+			return err
+		}
+
+		content = append(append(bytes.TrimSuffix(header, []byte("]}}")), '\n'), content...)
+		offset = 0
+	} else {
+		closing := make([]byte, len(harClosing))
+		if _, err := file.ReadAt(closing, offset); err != nil || string(closing) != harClosing {
+			return fmt.Errorf("%s is not a HAR file written by the provider, delete it to start a new recording", h.path)
+		}
+
+		content = append([]byte(",\n"), content...)
+	}
+
+	_, err = file.WriteAt(append(content, harClosing...), offset)
+
+	return err
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            int64       `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	Cookies     []harNameValue `json:"cookies"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Cookies     []harNameValue `json:"cookies"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    int64 `json:"send"`
+	Wait    int64 `json:"wait"`
+	Receive int64 `json:"receive"`
+}
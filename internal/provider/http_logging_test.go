@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoggingTransportWritesRedactedHar(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"value":"s3cret"}` {
+			t.Errorf("request body was not forwarded intact: %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errorMessages":["s3cret is invalid"]}`))
+	}))
+	defer server.Close()
+
+	harPath := filepath.Join(t.TempDir(), "requests.har")
+
+	redactor := newRedactor()
+	redactor.Add("s3cret")
+
+	client := &http.Client{
+		Transport: newLoggingTransport(http.DefaultTransport, redactor, newHarRecorder(harPath, "test")),
+	}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/object?token=s3cret", strings.NewReader(`{"value":"s3cret"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth("user@example.com", "api-token")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	// error bodies reach diagnostics through wrapAPIError, so they are redacted too
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"errorMessages":["REDACTED is invalid"]}` {
+		t.Errorf("error response body was not redacted: %s", body)
+	}
+
+	content, err := os.ReadFile(harPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{"s3cret", "api-token", "Basic "} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("HAR file contains %q:\n%s", secret, content)
+		}
+	}
+
+	var har map[string]harLog
+	if err := json.Unmarshal(content, &har); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := har["log"].Entries
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 HAR entry, got %d", len(entries))
+	}
+
+	if entries[0].Response.Status != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", entries[0].Response.Status)
+	}
+
+	if entries[0].Request.PostData == nil || entries[0].Request.PostData.Text != `{"value":"REDACTED"}` {
+		t.Errorf("unexpected request post data: %+v", entries[0].Request.PostData)
+	}
+}
+
+func TestHarRecorderAppendsAcrossProcesses(t *testing.T) {
+	harPath := filepath.Join(t.TempDir(), "requests.har")
+
+	// every provider process has its own recorder for the same file
+	for i, method := range []string{http.MethodGet, http.MethodPost, http.MethodDelete} {
+		recorder := newHarRecorder(harPath, "test")
+		if err := recorder.Record(harEntry{Request: harRequest{Method: method}}); err != nil {
+			t.Fatalf("recording entry %d: %s", i, err)
+		}
+	}
+
+	content, err := os.ReadFile(harPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var har map[string]harLog
+	if err := json.Unmarshal(content, &har); err != nil {
+		t.Fatalf("HAR file is not valid JSON: %s\n%s", err, content)
+	}
+
+	if har["log"].Version != "1.2" || har["log"].Creator.Name != "terraform-provider-jiraassets" {
+		t.Errorf("unexpected HAR log header: %+v", har["log"])
+	}
+
+	entries := har["log"].Entries
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 HAR entries, got %d", len(entries))
+	}
+
+	for i, method := range []string{http.MethodGet, http.MethodPost, http.MethodDelete} {
+		if entries[i].Request.Method != method {
+			t.Errorf("entry %d: expected method %s, got %s", i, method, entries[i].Request.Method)
+		}
+	}
+}
+
+func TestHarRecorderKeepsOtherFiles(t *testing.T) {
+	harPath := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(harPath, []byte("not a HAR file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := newHarRecorder(harPath, "test").Record(harEntry{}); err == nil {
+		t.Error("expected an error recording to a file that is not a HAR file")
+	}
+
+	content, err := os.ReadFile(harPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != "not a HAR file\n" {
+		t.Errorf("file was modified: %s", content)
+	}
+}
+
+func TestLoggingTransportKeepsSuccessfulBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"value":"s3cret"}`))
+	}))
+	defer server.Close()
+
+	redactor := newRedactor()
+	redactor.Add("s3cret")
+
+	client := &http.Client{
+		Transport: newLoggingTransport(http.DefaultTransport, redactor, nil),
+	}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"value":"s3cret"}` {
+		t.Errorf("response body was not returned intact: %s", body)
+	}
+}
@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	v3 "github.com/ctreminiom/go-atlassian/jira/v3"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// metadataCache caches object schemas, object types and attribute definitions
+// for the lifetime of the provider process, which is a single Terraform run.
+// It also caches the lookups used to convert typed attribute values, such as
+// status names, object keys and user email addresses.
+// Concurrent lookups of the same key are coalesced into a single API call.
+// Failed lookups are not cached so a later lookup can retry.
+type metadataCache struct {
+	client *assets.Client
+	jira   *v3.Client
+
+	mu      sync.Mutex
+	entries map[string]*metadataCacheEntry
+	hits    int
+	misses  int
+}
+
+// metadataCacheEntry holds a cached value, or a lookup in flight until ready is closed.
+type metadataCacheEntry struct {
+	ready chan struct{}
+	value interface{}
+	err   error
+}
+
+func newMetadataCache(client *assets.Client, jira *v3.Client) *metadataCache {
+	return &metadataCache{
+		client:  client,
+		jira:    jira,
+		entries: map[string]*metadataCacheEntry{},
+	}
+}
+
+// load returns the cached value for kind and key, calling fetch on a miss.
+// Callers asking for a key that is already being fetched wait for that call.
+// The fetch runs on a context detached from the caller that started it, so a
+// cancelled caller does not fail the others waiting for the same key; it is
+// bounded by the default read timeout instead.
+func (c *metadataCache) load(ctx context.Context, kind, key string, fetch func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	cacheKey := kind + "/" + key
+
+	c.mu.Lock()
+	entry, hit := c.entries[cacheKey]
+	if hit {
+		c.hits++
+	} else {
+		c.misses++
+		entry = &metadataCacheEntry{ready: make(chan struct{})}
+		c.entries[cacheKey] = entry
+	}
+	hits, misses := c.hits, c.misses
+	c.mu.Unlock()
+
+	tflog.Debug(ctx, "Metadata cache lookup", map[string]interface{}{
+		"kind":   kind,
+		"key":    key,
+		"hit":    hit,
+		"hits":   hits,
+		"misses": misses,
+	})
+
+	if !hit {
+		go c.fetch(context.WithoutCancel(ctx), cacheKey, entry, fetch)
+	}
+
+	select {
+	case <-entry.ready:
+		return entry.value, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fetch fills the cache entry and wakes up the callers waiting for it. A
+// failed fetch is removed from the cache before they wake up.
+func (c *metadataCache) fetch(ctx context.Context, cacheKey string, entry *metadataCacheEntry, fetch func(ctx context.Context) (interface{}, error)) {
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	defer cancel()
+
+	entry.value, entry.err = fetch(ctx)
+
+	if entry.err != nil {
+		c.mu.Lock()
+		delete(c.entries, cacheKey)
+		c.mu.Unlock()
+	}
+
+	close(entry.ready)
+}
+
+// ObjectSchemas returns every object schema in the workspace.
+func (c *metadataCache) ObjectSchemas(ctx context.Context, workspaceId string) ([]*models.ObjectSchemaScheme, error) {
+	value, err := c.load(ctx, "object_schemas", workspaceId, func(ctx context.Context) (interface{}, error) {
+		var objectSchemas []*models.ObjectSchemaScheme
+		for {
+			page, response, err := listObjectSchemas(ctx, c.client, workspaceId, len(objectSchemas), objectSchemaPageSize)
+			if err != nil {
+				return nil, fmt.Errorf("listing object schemas: %w", wrapAPIError(response, err))
+			}
+
+			objectSchemas = append(objectSchemas, page.Values...)
+			if len(page.Values) == 0 || len(objectSchemas) >= page.Total {
+				return objectSchemas, nil
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	objectSchemas, _ := value.([]*models.ObjectSchemaScheme)
+
+	return objectSchemas, nil
+}
+
+// ObjectSchema returns the object schema with the given ID.
+func (c *metadataCache) ObjectSchema(ctx context.Context, workspaceId, objectSchemaId string) (*models.ObjectSchemaScheme, error) {
+	value, err := c.load(ctx, "object_schema", workspaceId+"/"+objectSchemaId, func(ctx context.Context) (interface{}, error) {
+		schema, response, err := c.client.ObjectSchema.Get(ctx, workspaceId, objectSchemaId)
+		if err != nil {
+			return nil, fmt.Errorf("reading object schema %s: %w", objectSchemaId, wrapAPIError(response, err))
+		}
+		return schema, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	schema, _ := value.(*models.ObjectSchemaScheme)
+
+	return schema, nil
+}
+
+// ObjectTypes returns every object type in the object schema.
+func (c *metadataCache) ObjectTypes(ctx context.Context, workspaceId, objectSchemaId string) ([]*models.ObjectTypeScheme, error) {
+	value, err := c.load(ctx, "object_types", workspaceId+"/"+objectSchemaId, func(ctx context.Context) (interface{}, error) {
+		objectTypes, response, err := c.client.ObjectSchema.ObjectTypes(ctx, workspaceId, objectSchemaId, false)
+		if err != nil {
+			return nil, fmt.Errorf("listing object types of object schema %s: %w", objectSchemaId, wrapAPIError(response, err))
+		}
+		return objectTypes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	objectTypes, _ := value.([]*models.ObjectTypeScheme)
+
+	return objectTypes, nil
+}
+
+// ObjectType returns the object type with the given ID.
+func (c *metadataCache) ObjectType(ctx context.Context, workspaceId, objectTypeId string) (*models.ObjectTypeScheme, error) {
+	value, err := c.load(ctx, "object_type", workspaceId+"/"+objectTypeId, func(ctx context.Context) (interface{}, error) {
+		objectType, response, err := c.client.ObjectType.Get(ctx, workspaceId, objectTypeId)
+		if err != nil {
+			return nil, fmt.Errorf("reading object type %s: %w", objectTypeId, wrapAPIError(response, err))
+		}
+		return objectType, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	objectType, _ := value.(*models.ObjectTypeScheme)
+
+	return objectType, nil
+}
+
+// ObjectTypeAttributes returns the attribute definitions of the object type,
+// including attributes inherited from parent object types.
+func (c *metadataCache) ObjectTypeAttributes(ctx context.Context, workspaceId, objectTypeId string) ([]*models.ObjectTypeAttributeScheme, error) {
+	value, err := c.load(ctx, "object_type_attributes", workspaceId+"/"+objectTypeId, func(ctx context.Context) (interface{}, error) {
+		attributes, response, err := c.client.ObjectType.Attributes(ctx, workspaceId, objectTypeId, nil)
+		if err != nil {
+			return nil, fmt.Errorf("listing attributes of object type %s: %w", objectTypeId, wrapAPIError(response, err))
+		}
+		return attributes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	attributes, _ := value.([]*models.ObjectTypeAttributeScheme)
+
+	return attributes, nil
+}
+
+// StatusTypes returns the global statuses and the statuses of the object schema.
+func (c *metadataCache) StatusTypes(ctx context.Context, workspaceId, objectSchemaId string) ([]*statusType, error) {
+	value, err := c.load(ctx, "status_types", workspaceId+"/"+objectSchemaId, func(ctx context.Context) (interface{}, error) {
+		statuses, response, err := listStatusTypes(ctx, c.client, workspaceId, objectSchemaId)
+		if err != nil {
+			return nil, fmt.Errorf("listing statuses of object schema %s: %w", objectSchemaId, wrapAPIError(response, err))
+		}
+		return statuses, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, _ := value.([]*statusType)
+
+	return statuses, nil
+}
+
+// ObjectIdByKey returns the ID of the object with the given object key.
+func (c *metadataCache) ObjectIdByKey(ctx context.Context, workspaceId, objectKey string) (string, error) {
+	value, err := c.load(ctx, "object_key", workspaceId+"/"+objectKey, func(ctx context.Context) (interface{}, error) {
+		list, response, err := c.client.Object.Filter(ctx, workspaceId, "Key = "+aqlString(objectKey), false, 0, 2)
+		if err != nil {
+			return nil, fmt.Errorf("searching object %s: %w", objectKey, wrapAPIError(response, err))
+		}
+		if len(list.Values) != 1 {
+			return nil, fmt.Errorf("expected one object with key %s, found %d", objectKey, len(list.Values))
+		}
+		return list.Values[0].ID, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	objectId, _ := value.(string)
+
+	return objectId, nil
+}
+
+// AccountIdByEmail returns the Atlassian account ID of the user with the given
+// email address. It requires the provider site_url to be configured.
+func (c *metadataCache) AccountIdByEmail(ctx context.Context, email string) (string, error) {
+	if c.jira == nil {
+		return "", fmt.Errorf("the provider site_url must be configured to resolve the user email address %s", email)
+	}
+
+	value, err := c.load(ctx, "user_email", strings.ToLower(email), func(ctx context.Context) (interface{}, error) {
+		users, response, err := c.jira.User.Search.Do(ctx, "", email, 0, 10)
+		if err != nil {
+			return nil, fmt.Errorf("searching user %s: %w", email, wrapAPIError(response, err))
+		}
+
+		var matches []*models.UserScheme
+		for _, u := range users {
+			if strings.EqualFold(u.EmailAddress, email) {
+				matches = append(matches, u)
+			}
+		}
+
+		// email addresses are hidden by some privacy settings, a single search result is still unambiguous
+		if len(matches) == 0 && len(users) == 1 {
+			matches = users
+		}
+
+		if len(matches) != 1 {
+			return nil, fmt.Errorf("expected one user with email address %s, found %d", email, len(matches))
+		}
+
+		return matches[0].AccountID, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	accountId, _ := value.(string)
+
+	return accountId, nil
+}
+
+// aqlString quotes s as an AQL string literal.
+func aqlString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMetadataCacheCoalescesConcurrentLookups(t *testing.T) {
+	cache := newMetadataCache(nil, nil)
+
+	var calls int32
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			value, err := cache.load(context.Background(), "object_type", "ws/1", func(context.Context) (interface{}, error) {
+				atomic.AddInt32(&calls, 1)
+				<-release
+				return "Laptop", nil
+			})
+			if err != nil || value != "Laptop" {
+				t.Errorf("unexpected result %v, %v", value, err)
+			}
+		}()
+	}
+
+	// hold the fetch until every caller is waiting for it
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+		cache.mu.Lock()
+		lookups := cache.hits + cache.misses
+		cache.mu.Unlock()
+
+		if lookups == 10 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("only %d of 10 lookups started", lookups)
+		}
+	}
+
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("expected a single fetch, got %d", calls)
+	}
+
+	if cache.hits+cache.misses != 10 || cache.misses != 1 {
+		t.Errorf("unexpected statistics: %d hits, %d misses", cache.hits, cache.misses)
+	}
+}
+
+func TestMetadataCacheDoesNotCacheErrors(t *testing.T) {
+	cache := newMetadataCache(nil, nil)
+
+	_, err := cache.load(context.Background(), "object_type", "ws/1", func(context.Context) (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	value, err := cache.load(context.Background(), "object_type", "ws/1", func(context.Context) (interface{}, error) {
+		return "Laptop", nil
+	})
+	if err != nil || value != "Laptop" {
+		t.Errorf("expected the failed lookup to be retried, got %v, %v", value, err)
+	}
+}
+
+func TestMetadataCacheSurvivesCancelledCaller(t *testing.T) {
+	cache := newMetadataCache(nil, nil)
+
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) (interface{}, error) {
+		select {
+		case <-release:
+			return "Laptop", nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	// the caller that starts the fetch gives up before it completes
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := cache.load(ctx, "object_type", "ws/1", fetch)
+		first <- err
+	}()
+
+	second := make(chan interface{})
+	go func() {
+		for {
+			cache.mu.Lock()
+			started := cache.misses == 1
+			cache.mu.Unlock()
+			if started {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		value, err := cache.load(context.Background(), "object_type", "ws/1", fetch)
+		if err != nil {
+			t.Errorf("expected the waiting caller to get the value, got %v", err)
+		}
+		second <- value
+	}()
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cancelled caller to stop waiting, got %v", err)
+	}
+
+	close(release)
+	if value := <-second; value != "Laptop" {
+		t.Errorf("expected Laptop, got %v", value)
+	}
+}
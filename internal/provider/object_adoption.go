@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// findExistingObject returns the object of the planned object type whose
+// match_on attributes hold the planned values, or nil when adopt_existing is
+// not set or no object matches. Several matching objects are an error, as
+// Terraform cannot tell which one to adopt.
+func (r *objectResource) findExistingObject(ctx context.Context, workspaceId string, plan objectResourceModel, attributes []*models.ObjectPayloadAttributeScheme) (*models.ObjectScheme, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !plan.AdoptExisting.ValueBool() {
+		return nil, diags
+	}
+
+	var matchOn []string
+	diags.Append(plan.MatchOn.ElementsAs(ctx, &matchOn, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	definitions, err := r.metadata.ObjectTypeAttributes(ctx, workspaceId, plan.TypeId.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Object Type Attributes",
+			err.Error(),
+		)
+		return nil, diags
+	}
+
+	query, err := matchQuery(plan.TypeId.ValueString(), definitions, matchOn, attributes)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("match_on"),
+			"Unable to Match Existing Object",
+			err.Error(),
+		)
+		return nil, diags
+	}
+
+	tflog.Debug(ctx, "Searching existing object to adopt.", map[string]interface{}{
+		"aql": query,
+	})
+
+	list, response, err := r.client.Object.Filter(ctx, workspaceId, query, false, 0, 10)
+	if err != nil {
+		diags.AddError(
+			"Unable to Search Existing Objects",
+			wrapAPIError(response, err).Error(),
+		)
+		return nil, diags
+	}
+
+	switch len(list.Values) {
+	case 0:
+		return nil, diags
+	case 1:
+		return list.Values[0], diags
+	default:
+		total := list.Total
+		if total < len(list.Values) {
+			total = len(list.Values)
+		}
+
+		var keys []string
+		for _, object := range list.Values {
+			keys = append(keys, object.ObjectKey)
+		}
+
+		diags.AddAttributeError(
+			path.Root("match_on"),
+			"Multiple Existing Objects Match",
+			fmt.Sprintf("%d objects match %s, including %s. Add attributes to match_on so a single object matches, "+
+				"or import the object to adopt with terraform import.", total, query, strings.Join(keys, ", ")),
+		)
+		return nil, diags
+	}
+}
+
+// matchQuery returns the AQL query for objects of the object type whose
+// match_on attributes hold the values of the payload attributes.
+func matchQuery(typeId string, definitions []*models.ObjectTypeAttributeScheme, matchOn []string, attributes []*models.ObjectPayloadAttributeScheme) (string, error) {
+	clauses := []string{"objectTypeId = " + typeId}
+
+	for _, name := range matchOn {
+		definition := findAttributeByName(definitions, name)
+		if definition == nil {
+			return "", fmt.Errorf("object type %s has no attribute named %q", typeId, name)
+		}
+
+		value, ok := payloadAttributeValue(attributes, definition.ID)
+		if !ok {
+			return "", fmt.Errorf("attribute %q is in match_on but has no configured value", name)
+		}
+
+		clauses = append(clauses, aqlString(definition.Name)+" = "+aqlString(value))
+	}
+
+	return strings.Join(clauses, " AND "), nil
+}
+
+// payloadAttributeValue returns the first value of the payload attribute with the given ID.
+func payloadAttributeValue(attributes []*models.ObjectPayloadAttributeScheme, id string) (string, bool) {
+	for _, attribute := range attributes {
+		if attribute.ObjectTypeAttributeID == id && len(attribute.ObjectAttributeValues) > 0 {
+			return attribute.ObjectAttributeValues[0].Value, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+func TestMatchQuery(t *testing.T) {
+	definitions := []*models.ObjectTypeAttributeScheme{
+		{ID: "1087", Name: "Name"},
+		{ID: "1090", Name: "Serial Number"},
+	}
+
+	attributes := []*models.ObjectPayloadAttributeScheme{
+		{ObjectTypeAttributeID: "1087", ObjectAttributeValues: []*models.ObjectPayloadAttributeValueScheme{{Value: `My "Phone"`}}},
+		{ObjectTypeAttributeID: "1090", ObjectAttributeValues: []*models.ObjectPayloadAttributeValueScheme{{Value: "ABC-123"}}},
+	}
+
+	query, err := matchQuery("117", definitions, []string{"Serial Number", "Name"}, attributes)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `objectTypeId = 117 AND "Serial Number" = "ABC-123" AND "Name" = "My \"Phone\""`
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+
+	if _, err := matchQuery("117", definitions, []string{"Owner"}, attributes); err == nil {
+		t.Error("expected an error for an unknown attribute name")
+	}
+
+	if _, err := matchQuery("117", definitions, []string{"Serial Number"}, attributes[:1]); err == nil {
+		t.Error("expected an error for an attribute without a configured value")
+	}
+}
@@ -0,0 +1,625 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &objectAttributeResource{}
+	_ resource.ResourceWithConfigure = &objectAttributeResource{}
+
+	_ resource.ResourceWithValidateConfig = &objectAttributeResource{}
+	_ resource.ResourceWithModifyPlan     = &objectAttributeResource{}
+)
+
+// Values of on_destroy.
+const (
+	// onDestroyClear removes every value of the attribute.
+	onDestroyClear = "clear"
+
+	// onDestroyRestore sets the values the attribute had before it was managed.
+	onDestroyRestore = "restore"
+)
+
+// NewObjectAttributeResource is a helper function to simplify the provider implementation.
+func NewObjectAttributeResource() resource.Resource {
+	return &objectAttributeResource{}
+}
+
+// objectAttributeResource manages the values of a single attribute of an
+// object that is not managed by Terraform.
+type objectAttributeResource struct {
+	client       *assets.Client
+	workspace_id string
+	metadata     *metadataCache
+}
+
+// Metadata returns the resource type name.
+func (r *objectAttributeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_attribute"
+}
+
+type objectAttributeResourceModel struct {
+	Id             types.String   `tfsdk:"id"`
+	WorkspaceId    types.String   `tfsdk:"workspace_id"`
+	ObjectId       types.String   `tfsdk:"object_id"`
+	ObjectKey      types.String   `tfsdk:"object_key"`
+	TypeId         types.String   `tfsdk:"type_id"`
+	AttributeId    types.String   `tfsdk:"attribute_id"`
+	AttributeName  types.String   `tfsdk:"attribute_name"`
+	Values         types.List     `tfsdk:"values"`
+	OnDestroy      types.String   `tfsdk:"on_destroy"`
+	PreviousValues types.List     `tfsdk:"previous_values"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Schema defines the schema for the resource.
+func (r *objectAttributeResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the values of a single attribute of an existing object, leaving the object and its other attributes to their owners. " +
+			"Drift is only detected on the managed attribute.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the object and the ID of the attribute type separated by a slash.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workspace_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the workspace of the object. Defaults to the provider workspace_id.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"object_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the object. Exactly one of object_id and object_key must be set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"object_key": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The object key of the object, such as ITSM-88. Exactly one of object_id and object_key must be set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"type_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the object type of the object.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"attribute_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the attribute type. Exactly one of attribute_id and attribute_name must be set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"attribute_name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the attribute type, such as \"Cost Center\". Exactly one of attribute_id and attribute_name must be set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"values": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "The values of the attribute as the API accepts them: the object ID for references, the status ID for statuses, " +
+					"the account ID for users and the group name for groups.",
+			},
+			"on_destroy": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(onDestroyClear),
+				Description: "What happens to the attribute on destroy: \"clear\" removes its values, \"restore\" sets the values it had before it was managed. Defaults to \"clear\".",
+			},
+			"previous_values": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The values the attribute had before it was managed, restored on destroy when on_destroy is \"restore\".",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// ValidateConfig checks that the object and the attribute are each set once.
+func (r *objectAttributeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config objectAttributeResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.ObjectId.IsNull() == config.ObjectKey.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("object_id"),
+			"Invalid Object",
+			"Exactly one of object_id and object_key must be set.",
+		)
+	}
+
+	if config.AttributeId.IsNull() == config.AttributeName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("attribute_id"),
+			"Invalid Attribute",
+			"Exactly one of attribute_id and attribute_name must be set.",
+		)
+	}
+
+	switch config.OnDestroy.ValueString() {
+	case "", onDestroyClear, onDestroyRestore:
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("on_destroy"),
+			"Invalid On Destroy",
+			fmt.Sprintf("on_destroy must be %q or %q. Got: %q", onDestroyClear, onDestroyRestore, config.OnDestroy.ValueString()),
+		)
+	}
+
+	if !config.Values.IsNull() && !config.Values.IsUnknown() && len(config.Values.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("values"),
+			"Missing Attribute Values",
+			"values must hold at least one value, destroy the resource to clear the attribute.",
+		)
+	}
+}
+
+// ModifyPlan resolves the object and the attribute of a new resource, and
+// validates the planned values against the attribute definition.
+func (r *objectAttributeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	ctx, done, diags := withPlanTimeout(ctx, req.Plan)
+	defer done(&resp.Diagnostics)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan objectAttributeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if (plan.ObjectId.IsUnknown() && plan.ObjectKey.IsUnknown()) || (plan.AttributeId.IsUnknown() && plan.AttributeName.IsUnknown()) {
+		return
+	}
+
+	workspaceId := workspaceIdOrDefault(plan.WorkspaceId, r.workspace_id)
+
+	var state objectAttributeResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// an existing resource keeps the object type it was read with, so only
+	// the attribute definitions are looked up, which are cached
+	var object *models.ObjectScheme
+	var definition *models.ObjectTypeAttributeScheme
+	var err error
+	if !req.State.Raw.IsNull() && plan.ObjectId.Equal(state.ObjectId) && plan.ObjectKey.Equal(state.ObjectKey) && !state.TypeId.IsNull() {
+		definition, err = r.resolveDefinition(ctx, workspaceId, state.TypeId.ValueString(), plan)
+	} else {
+		object, definition, err = r.resolve(ctx, workspaceId, plan)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Resolve Object Attribute",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(validateEditable(path.Root("values"), definition)...)
+
+	if !plan.Values.IsUnknown() {
+		var values []types.String
+		resp.Diagnostics.Append(plan.Values.ElementsAs(ctx, &values, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for i, value := range values {
+			if !value.IsUnknown() {
+				resp.Diagnostics.Append(validateAttributeValue(path.Root("values").AtListIndex(i), definition, value.ValueString(), false)...)
+			}
+		}
+		resp.Diagnostics.Append(validateCardinality(path.Root("values"), definition, len(values))...)
+	}
+
+	if plan.OnDestroy.ValueString() == onDestroyClear && definition.MinimumCardinality > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("on_destroy"),
+			"Attribute Cannot Be Cleared",
+			fmt.Sprintf("Attribute %q is mandatory, so it cannot be cleared on destroy. Set on_destroy to %q.", definition.Name, onDestroyRestore),
+		)
+	}
+
+	if resp.Diagnostics.HasError() || !req.State.Raw.IsNull() {
+		return
+	}
+
+	plan.setTarget(workspaceId, object, definition)
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// resolve returns the object and the definition of the attribute the
+// resource manages, looking them up by ID or by key and name.
+func (r *objectAttributeResource) resolve(ctx context.Context, workspaceId string, m objectAttributeResourceModel) (*models.ObjectScheme, *models.ObjectTypeAttributeScheme, error) {
+	objectId := m.ObjectId.ValueString()
+	if m.ObjectId.IsNull() || m.ObjectId.IsUnknown() {
+		id, err := r.metadata.ObjectIdByKey(ctx, workspaceId, m.ObjectKey.ValueString())
+		if err != nil {
+			return nil, nil, err
+		}
+		objectId = id
+	}
+
+	object, response, err := r.client.Object.Get(ctx, workspaceId, objectId)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading object %s: %w", objectId, wrapAPIError(response, err))
+	}
+	if object.ObjectType == nil {
+		return nil, nil, fmt.Errorf("object %s has no object type", objectId)
+	}
+
+	definition, err := r.resolveDefinition(ctx, workspaceId, object.ObjectType.Id, m)
+	if err != nil {
+		return nil, nil, fmt.Errorf("object %s: %w", object.ObjectKey, err)
+	}
+
+	return object, definition, nil
+}
+
+// resolveDefinition returns the definition of the attribute the resource
+// manages on the given object type, looking it up by ID or by name.
+func (r *objectAttributeResource) resolveDefinition(ctx context.Context, workspaceId, typeId string, m objectAttributeResourceModel) (*models.ObjectTypeAttributeScheme, error) {
+	definitions, err := r.metadata.ObjectTypeAttributes(ctx, workspaceId, typeId)
+	if err != nil {
+		return nil, err
+	}
+
+	var definition *models.ObjectTypeAttributeScheme
+	if m.AttributeId.IsNull() || m.AttributeId.IsUnknown() {
+		definition = findAttributeByName(definitions, m.AttributeName.ValueString())
+	} else {
+		definition = findAttributeById(definitions, m.AttributeId.ValueString())
+	}
+	if definition == nil {
+		return nil, fmt.Errorf("object type %s has no attribute %s%s",
+			typeId, m.AttributeId.ValueString(), m.AttributeName.ValueString())
+	}
+
+	return definition, nil
+}
+
+// setTarget sets the IDs and names of the object and the attribute.
+func (m *objectAttributeResourceModel) setTarget(workspaceId string, object *models.ObjectScheme, definition *models.ObjectTypeAttributeScheme) {
+	m.Id = types.StringValue(object.ID + "/" + definition.ID)
+	m.WorkspaceId = types.StringValue(workspaceId)
+	m.ObjectId = types.StringValue(object.ID)
+	m.ObjectKey = types.StringValue(object.ObjectKey)
+	m.TypeId = types.StringValue(object.ObjectType.Id)
+	m.AttributeId = types.StringValue(definition.ID)
+	m.AttributeName = types.StringValue(definition.Name)
+}
+
+// setValues sets the values of the attribute, an empty list clears it.
+func (r *objectAttributeResource) setValues(ctx context.Context, workspaceId string, m objectAttributeResourceModel, values []string) (*models.ResponseScheme, error) {
+	payloadValues := []*models.ObjectPayloadAttributeValueScheme{}
+	for _, value := range values {
+		payloadValues = append(payloadValues, &models.ObjectPayloadAttributeValueScheme{Value: value})
+	}
+
+	payload := &objectUpdatePayload{
+		ObjectTypeID: m.TypeId.ValueString(),
+		Attributes: []*objectUpdateAttribute{
+			{
+				ObjectTypeAttributeID: m.AttributeId.ValueString(),
+				ObjectAttributeValues: payloadValues,
+			},
+		},
+	}
+
+	_, response, err := updateObject(ctx, r.client, workspaceId, m.ObjectId.ValueString(), payload)
+	if err != nil {
+		return response, wrapAPIError(response, err)
+	}
+
+	return response, nil
+}
+
+// Create records the current values of the attribute and sets the configured ones.
+func (r *objectAttributeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan objectAttributeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, "create", createTimeout)
+	defer done(&resp.Diagnostics)
+
+	workspaceId := workspaceIdOrDefault(plan.WorkspaceId, r.workspace_id)
+
+	// values unknown at plan time are resolved now
+	object, definition, err := r.resolve(ctx, workspaceId, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Resolve Object Attribute",
+			err.Error(),
+		)
+		return
+	}
+	plan.setTarget(workspaceId, object, definition)
+
+	attrs, response, err := getObjectAttributes(ctx, r.client, workspaceId, object.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Object Attributes",
+			wrapAPIError(response, err).Error(),
+		)
+		return
+	}
+
+	previous := append([]string{}, objectAttributeValues(attrs, definition.ID)...)
+
+	plan.PreviousValues, diags = types.ListValueFrom(ctx, types.StringType, previous)
+	resp.Diagnostics.Append(diags...)
+
+	var values []string
+	resp.Diagnostics.Append(plan.Values.ElementsAs(ctx, &values, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Setting object attribute.", map[string]interface{}{
+		"Id":              object.ID,
+		"attribute":       definition.Name,
+		"previous_values": previous,
+	})
+
+	if _, err := r.setValues(ctx, workspaceId, plan, values); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Set Object Attribute",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the values of the managed attribute.
+func (r *objectAttributeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state objectAttributeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, "read", readTimeout)
+	defer done(&resp.Diagnostics)
+
+	workspaceId := workspaceIdOrDefault(state.WorkspaceId, r.workspace_id)
+
+	attrs, response, err := getObjectAttributes(ctx, r.client, workspaceId, state.ObjectId.ValueString())
+	if isNotFound(response) {
+		tflog.Warn(ctx, "Object no longer exists, removing the attribute from state.", map[string]interface{}{
+			"Id": state.ObjectId.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Object Attributes",
+			wrapAPIError(response, err).Error(),
+		)
+		return
+	}
+
+	definitions, err := r.metadata.ObjectTypeAttributes(ctx, workspaceId, state.TypeId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Object Type Attributes",
+			err.Error(),
+		)
+		return
+	}
+
+	var prior []string
+	resp.Diagnostics.Append(state.Values.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// keep the configured representation and order of unchanged values
+	entries := objectAttributeEntries(attrs, state.AttributeId.ValueString())
+	refreshed := keepValueOrder(prior, matchPriorValues(findAttributeById(definitions, state.AttributeId.ValueString()), prior, entries))
+	if refreshed == nil {
+		refreshed = []string{}
+	}
+
+	state.WorkspaceId = types.StringValue(workspaceId)
+	state.Values, diags = types.ListValueFrom(ctx, types.StringType, refreshed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update sets the configured values of the attribute.
+func (r *objectAttributeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state objectAttributeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, "update", updateTimeout)
+	defer done(&resp.Diagnostics)
+
+	workspaceId := workspaceIdOrDefault(state.WorkspaceId, r.workspace_id)
+
+	// changing on_destroy alone does not touch the object
+	if !plan.Values.Equal(state.Values) {
+		var values []string
+		resp.Diagnostics.Append(plan.Values.ElementsAs(ctx, &values, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if _, err := r.setValues(ctx, workspaceId, state, values); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Set Object Attribute",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// destroyDeletes returns false, destroying the resource only clears or restores
+// the values of the attribute and keeps the object.
+func (r *objectAttributeResource) destroyDeletes(ctx context.Context, state tfsdk.State) (bool, diag.Diagnostics) {
+	return false, nil
+}
+
+// Delete clears the attribute or restores its previous values.
+func (r *objectAttributeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state objectAttributeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, "delete", deleteTimeout)
+	defer done(&resp.Diagnostics)
+
+	workspaceId := workspaceIdOrDefault(state.WorkspaceId, r.workspace_id)
+
+	values := []string{}
+	if state.OnDestroy.ValueString() == onDestroyRestore && !state.PreviousValues.IsNull() {
+		resp.Diagnostics.Append(state.PreviousValues.ElementsAs(ctx, &values, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Info(ctx, "Releasing object attribute.", map[string]interface{}{
+		"Id":         state.ObjectId.ValueString(),
+		"attribute":  state.AttributeName.ValueString(),
+		"on_destroy": state.OnDestroy.ValueString(),
+	})
+
+	response, err := r.setValues(ctx, workspaceId, state, values)
+	// the attribute is gone with its object
+	if isNotFound(response) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Release Object Attribute",
+			err.Error(),
+		)
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *objectAttributeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(JiraAssetsProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected JiraAssetsProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerClient.client
+	r.workspace_id = providerClient.workspaceId
+	r.metadata = providerClient.metadata
+}
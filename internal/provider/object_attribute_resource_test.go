@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccJiraAssetsObjectAttributeResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `resource "jiraassets_object" "test" {
+					type_id = "117"
+					attributes = [
+						{
+							attr_type_id = "1087"
+							attr_value   = "My Owned Phone"
+						},
+					]
+				}
+
+				resource "jiraassets_object_attribute" "test" {
+					object_id    = jiraassets_object.test.id
+					attribute_id = "1090"
+					values       = ["SN-1234"]
+					on_destroy   = "restore"
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jiraassets_object_attribute.test", "values.#", "1"),
+					resource.TestCheckResourceAttr("jiraassets_object_attribute.test", "values.0", "SN-1234"),
+					resource.TestCheckResourceAttr("jiraassets_object_attribute.test", "previous_values.#", "0"),
+					resource.TestCheckResourceAttr("jiraassets_object_attribute.test", "type_id", "117"),
+					resource.TestCheckResourceAttrSet("jiraassets_object_attribute.test", "object_key"),
+					resource.TestCheckResourceAttrSet("jiraassets_object_attribute.test", "attribute_name"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: `resource "jiraassets_object" "test" {
+					type_id = "117"
+					attributes = [
+						{
+							attr_type_id = "1087"
+							attr_value   = "My Owned Phone"
+						},
+					]
+				}
+
+				resource "jiraassets_object_attribute" "test" {
+					object_id    = jiraassets_object.test.id
+					attribute_id = "1090"
+					values       = ["SN-5678"]
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jiraassets_object_attribute.test", "values.0", "SN-5678"),
+					resource.TestCheckResourceAttr("jiraassets_object_attribute.test", "on_destroy", "clear"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccJiraAssetsObjectAttributeResource_invalidTarget(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "jiraassets_object_attribute" "test" {
+					object_id      = "1"
+					object_key     = "ITSM-1"
+					attribute_name = "Cost Center"
+					values         = ["CC-4711"]
+				}`,
+				ExpectError: regexp.MustCompile("Exactly one of object_id and object_key must be set"),
+			},
+		},
+	})
+}
+
+func TestObjectAttributeModifyPlanUsesStateType(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/objecttype/117/attributes") {
+			_, _ = w.Write([]byte(`[{"id":"1087","name":"Cost Center","type":0,"editable":true,"maximumCardinality":1}]`))
+			return
+		}
+
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := assets.New(nil, server.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &objectAttributeResource{client: client, workspace_id: "ws", metadata: newMetadataCache(client, nil)}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	// an update of an existing resource, which already knows its object type
+	value := func(attrValue string) tftypes.Value {
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		for name, v := range map[string]string{"id": "42/1087", "workspace_id": "ws", "object_id": "42", "object_key": "ITSM-42", "type_id": "117", "attribute_id": "1087", "attribute_name": "Cost Center", "on_destroy": onDestroyClear} {
+			if diags := state.SetAttribute(ctx, path.Root(name), types.StringValue(v)); diags.HasError() {
+				t.Fatal(diags)
+			}
+		}
+		if diags := state.SetAttribute(ctx, path.Root("values"), []string{attrValue}); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return state.Raw
+	}
+
+	req := fwresource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: value("CC-2")},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: value("CC-1")},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: value("CC-2")},
+	}
+	resp := fwresource.ModifyPlanResponse{Plan: req.Plan}
+
+	r.ModifyPlan(ctx, req, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
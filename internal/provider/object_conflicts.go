@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// fieldValue returns the value of the field that holds the value of the attribute.
+func (m objectAttrResourceModel) fieldValue() attr.Value {
+	switch m.valueField() {
+	case "attr_values":
+		return m.AttrValues
+	case "reference_object_key":
+		return m.ReferenceObjectKey
+	case "user_email":
+		return m.UserEmail
+	case "account_id":
+		return m.AccountId
+	case "group_name":
+		return m.GroupName
+	case "date_value":
+		return m.DateValue
+	case "bool_value":
+		return m.BoolValue
+	case "number_value":
+		return m.NumberValue
+	case "select_value":
+		return m.SelectValue
+	case "status_name":
+		return m.StatusName
+	default:
+		return m.AttrValue
+	}
+}
+
+// attributeLabel returns the name and ID of an attribute for a diagnostic.
+func attributeLabel(definitions []*models.ObjectTypeAttributeScheme, id string) string {
+	if definition := findAttributeById(definitions, id); definition != nil {
+		return fmt.Sprintf("%q (%s)", definition.Name, id)
+	}
+
+	return id
+}
+
+// checkConflicts reports an error when the object was updated since Terraform
+// last read it and a managed attribute no longer holds the value in the prior
+// state, so the update does not overwrite a change made outside of Terraform.
+// Changes to attributes Terraform does not manage are not conflicts.
+// referenceIds are the objects the aql references selected at the last read.
+func (r *objectResource) checkConflicts(ctx context.Context, workspaceId string, state objectResourceModel, referenceIds map[string][]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	object, response, err := r.client.Object.Get(ctx, workspaceId, state.Id.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Unable to Check for Conflicts",
+			wrapAPIError(response, err).Error(),
+		)
+		return diags
+	}
+
+	if object.Updated == state.Updated.ValueString() {
+		return diags
+	}
+
+	attrs, response, err := getObjectAttributes(ctx, r.client, workspaceId, state.Id.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Unable to Check for Conflicts",
+			wrapAPIError(response, err).Error(),
+		)
+		return diags
+	}
+
+	definitions, err := r.metadata.ObjectTypeAttributes(ctx, workspaceId, state.TypeId.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Object Type Attributes",
+			err.Error(),
+		)
+		return diags
+	}
+
+	conflicts, d := r.conflictingAttributes(ctx, workspaceId, state, definitions, attrs, referenceIds)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if len(conflicts) == 0 {
+		tflog.Debug(ctx, "Object was updated outside of Terraform, no managed attribute changed.", map[string]interface{}{
+			"Id":      state.Id.ValueString(),
+			"updated": object.Updated,
+		})
+		return diags
+	}
+
+	diags.AddError(
+		"Object Changed Outside of Terraform",
+		fmt.Sprintf("Object %s was updated at %s, after Terraform last read it at %s. These managed attributes changed since then "+
+			"(state value -> current value):\n\n%s\n\nRun terraform apply again to plan the change against the current values, "+
+			"or set conflict_detection to false to overwrite them.",
+			state.ObjectKey.ValueString(), object.Updated, state.Updated.ValueString(), strings.Join(conflicts, "\n")),
+	)
+
+	return diags
+}
+
+// conflictingAttributes returns a line for every managed attribute whose
+// current value differs from the prior state. Write-only attributes are not
+// in the state and cannot be compared, and neither can aql references without
+// IDs recorded at the last read.
+func (r *objectResource) conflictingAttributes(ctx context.Context, workspaceId string, state objectResourceModel, definitions []*models.ObjectTypeAttributeScheme, attrs []*objectAttribute, referenceIds map[string][]string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	resolver := attributeValueResolver{
+		metadata:     r.metadata,
+		workspaceId:  workspaceId,
+		objectTypeId: state.TypeId.ValueString(),
+		definitions:  definitions,
+	}
+
+	var conflicts []string
+	conflict := func(id, prior, current string) {
+		conflicts = append(conflicts, fmt.Sprintf("  - %s: %s -> %s", attributeLabel(definitions, id), prior, current))
+	}
+
+	for _, prior := range state.Attributes {
+		refreshed, found, d := resolver.fromAPI(ctx, prior, objectAttributeEntries(attrs, prior.AttrTypeId.ValueString()))
+		diags.Append(d...)
+
+		switch {
+		case !found:
+			conflict(prior.AttrTypeId.ValueString(), prior.fieldValue().String(), "(no value)")
+		case !prior.fieldValue().Equal(refreshed.fieldValue()):
+			conflict(prior.AttrTypeId.ValueString(), prior.fieldValue().String(), refreshed.fieldValue().String())
+		}
+	}
+
+	for _, sensitive := range state.SensitiveAttributes {
+		prior := sensitive.attribute()
+		refreshed, found, d := resolver.fromAPI(ctx, prior, objectAttributeEntries(attrs, prior.AttrTypeId.ValueString()))
+		diags.Append(d...)
+
+		if !found || !prior.AttrValue.Equal(refreshed.AttrValue) {
+			conflict(prior.AttrTypeId.ValueString(), "(sensitive value)", "(sensitive value)")
+		}
+	}
+
+	if !state.AttributeValues.IsNull() {
+		var priorValues, attributeIds map[string]string
+		diags.Append(state.AttributeValues.ElementsAs(ctx, &priorValues, false)...)
+		diags.Append(state.AttributeIds.ElementsAs(ctx, &attributeIds, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		for name, prior := range priorValues {
+			id := attributeIds[name]
+			entries := objectAttributeEntries(attrs, id)
+
+			switch {
+			case len(entries) == 0:
+				conflict(id, fmt.Sprintf("%q", prior), "(no value)")
+			case !matchesAttributeValue(findAttributeById(definitions, id), prior, entries[0]):
+				conflict(id, fmt.Sprintf("%q", prior), fmt.Sprintf("%q", entries[0].rawValue()))
+			}
+		}
+	}
+
+	// aql references are compared with the objects they referenced at the last
+	// read, running the query again would report newly matching objects
+	for _, prior := range state.References {
+		id := prior.AttrTypeId.ValueString()
+		entries := objectAttributeEntries(attrs, id)
+
+		if !prior.Aql.IsNull() {
+			priorIds, ok := referenceIds[id]
+			if current := referencedIds(entries); ok && strings.Join(priorIds, ",") != strings.Join(current, ",") {
+				conflict(id, fmt.Sprintf("%v", priorIds), fmt.Sprintf("%v", current))
+			}
+			continue
+		}
+
+		refreshed, d := refreshReference(ctx, prior, entries, nil)
+		diags.Append(d...)
+		if !prior.ObjectIds.Equal(refreshed.ObjectIds) || !prior.ObjectKeys.Equal(refreshed.ObjectKeys) {
+			conflict(id, referenceString(prior), referenceString(refreshed))
+		}
+	}
+
+	sort.Strings(conflicts)
+
+	return conflicts, diags
+}
+
+// referenceString returns the referenced objects of a reference for a diagnostic.
+func referenceString(m objectReferenceModel) string {
+	var parts []string
+	if !m.ObjectIds.IsNull() {
+		parts = append(parts, "object_ids = "+m.ObjectIds.String())
+	}
+	if !m.ObjectKeys.IsNull() {
+		parts = append(parts, "object_keys = "+m.ObjectKeys.String())
+	}
+	if !m.Aql.IsNull() {
+		parts = append(parts, "aql = "+m.Aql.String())
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestConflictingAttributes(t *testing.T) {
+	definitions := []*models.ObjectTypeAttributeScheme{
+		{ID: "2", Name: "Name", Editable: true},
+		{ID: "3", Name: "Serial Number", Editable: true},
+		{ID: "4", Name: "Notes", Editable: true},
+		{ID: "5", Name: "Owner", Editable: true},
+		{ID: "6", Name: "Rack", Editable: true, Type: attributeTypeReference},
+	}
+
+	attribute := func(id, value string) objectAttrResourceModel {
+		m := objectAttrResourceModel{AttrTypeId: types.StringValue(id)}
+		m.setNullValues()
+		m.AttrValue = types.StringValue(value)
+		return m
+	}
+
+	state := objectResourceModel{
+		TypeId:     types.StringValue("117"),
+		Attributes: []objectAttrResourceModel{attribute("2", "My Phone"), attribute("3", "ABC-123"), attribute("4", "Managed")},
+		AttributeValues: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"Owner": types.StringValue("alice"),
+		}),
+		AttributeIds: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"Owner": types.StringValue("5"),
+		}),
+		References: []objectReferenceModel{
+			{
+				AttrTypeId: types.StringValue("6"),
+				ObjectIds:  types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10")}),
+				ObjectKeys: types.SetNull(types.StringType),
+				Aql:        types.StringNull(),
+			},
+		},
+	}
+
+	attrs := []*objectAttribute{
+		{ObjectTypeAttributeId: "2", ObjectAttributeValues: []*objectAttributeValue{{Value: "My Phone"}}},
+		{ObjectTypeAttributeId: "3", ObjectAttributeValues: []*objectAttributeValue{{Value: "XYZ-999"}}},
+		{ObjectTypeAttributeId: "5", ObjectAttributeValues: []*objectAttributeValue{{Value: "bob"}}},
+		{ObjectTypeAttributeId: "6", ObjectAttributeValues: []*objectAttributeValue{{ReferencedObject: &referencedObject{ID: "10", ObjectKey: "ITSM-10"}}}},
+		{ObjectTypeAttributeId: "7", ObjectAttributeValues: []*objectAttributeValue{{Value: "unmanaged change"}}},
+	}
+
+	conflicts, diags := (&objectResource{}).conflictingAttributes(context.Background(), "workspace", state, definitions, attrs, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []string{
+		`  - "Notes" (4): "Managed" -> (no value)`,
+		`  - "Owner" (5): "alice" -> "bob"`,
+		`  - "Serial Number" (3): "ABC-123" -> "XYZ-999"`,
+	}
+	if !reflect.DeepEqual(conflicts, expected) {
+		t.Errorf("expected %q, got %q", expected, conflicts)
+	}
+}
+
+func TestConflictingAqlReferences(t *testing.T) {
+	definitions := []*models.ObjectTypeAttributeScheme{
+		{ID: "6", Name: "Rack", Editable: true, Type: attributeTypeReference},
+		{ID: "8", Name: "Switch", Editable: true, Type: attributeTypeReference},
+		{ID: "9", Name: "Room", Editable: true, Type: attributeTypeReference},
+	}
+
+	reference := func(id string) objectReferenceModel {
+		return objectReferenceModel{
+			AttrTypeId: types.StringValue(id),
+			ObjectIds:  types.SetNull(types.StringType),
+			ObjectKeys: types.SetNull(types.StringType),
+			Aql:        types.StringValue(`objectType = "Racks"`),
+		}
+	}
+
+	state := objectResourceModel{
+		TypeId:     types.StringValue("117"),
+		References: []objectReferenceModel{reference("6"), reference("8"), reference("9")},
+	}
+
+	attrs := []*objectAttribute{
+		{ObjectTypeAttributeId: "6", ObjectAttributeValues: []*objectAttributeValue{{ReferencedObject: &referencedObject{ID: "10"}}}},
+		{ObjectTypeAttributeId: "8", ObjectAttributeValues: []*objectAttributeValue{{ReferencedObject: &referencedObject{ID: "20"}}, {ReferencedObject: &referencedObject{ID: "21"}}}},
+		{ObjectTypeAttributeId: "9", ObjectAttributeValues: []*objectAttributeValue{{ReferencedObject: &referencedObject{ID: "30"}}}},
+	}
+
+	// the queries may match other objects by now, only the references the
+	// object holds are compared; Room has no IDs recorded and is skipped
+	referenceIds := map[string][]string{
+		"6": {"10"},
+		"8": {"20"},
+	}
+
+	conflicts, diags := (&objectResource{}).conflictingAttributes(context.Background(), "workspace", state, definitions, attrs, referenceIds)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []string{
+		`  - "Switch" (8): [20] -> [20 21]`,
+	}
+	if !reflect.DeepEqual(conflicts, expected) {
+		t.Errorf("expected %q, got %q", expected, conflicts)
+	}
+}
+
+func TestAccJiraAssetsObjectResource_conflictDetection(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "jiraassets_object" "test" {
+					type_id            = "117"
+					conflict_detection = true
+					attributes = [
+						{
+							attr_type_id = "1087"
+							attr_value   = "My Guarded Phone"
+						},
+					]
+				}`,
+			},
+			// an update of an object unchanged since the refresh succeeds
+			{
+				Config: `resource "jiraassets_object" "test" {
+					type_id            = "117"
+					conflict_detection = true
+					attributes = [
+						{
+							attr_type_id = "1087"
+							attr_value   = "My Renamed Guarded Phone"
+						},
+					]
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jiraassets_object.test", "label", "My Renamed Guarded Phone"),
+				),
+			},
+		},
+	})
+}
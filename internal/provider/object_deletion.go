@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Values of deletion_policy.
+const (
+	// deletionPolicyDelete deletes the object.
+	deletionPolicyDelete = "delete"
+
+	// deletionPolicyArchive keeps the object, setting its status to the
+	// archive status and optionally moving it to an archive object type.
+	deletionPolicyArchive = "archive"
+
+	// deletionPolicyAbandon keeps the object unchanged, only removing it from state.
+	deletionPolicyAbandon = "abandon"
+)
+
+// destroyDeletes returns whether destroying the object deletes it, so the
+// abandon and archive deletion policies are allowed under prevent_deletes.
+func (r *objectResource) destroyDeletes(ctx context.Context, state tfsdk.State) (bool, diag.Diagnostics) {
+	var deletionPolicy types.String
+	diags := state.GetAttribute(ctx, path.Root("deletion_policy"), &deletionPolicy)
+
+	return deletionPolicy.IsNull() || deletionPolicy.ValueString() == deletionPolicyDelete, diags
+}
+
+// objectArchiveModel configures how the archive deletion policy archives an object.
+type objectArchiveModel struct {
+	StatusAttribute types.String `tfsdk:"status_attribute"`
+	Status          types.String `tfsdk:"status"`
+	ObjectTypeId    types.String `tfsdk:"object_type_id"`
+}
+
+// archiveSchemaAttribute returns the schema of the archive attribute, which
+// configures how objects are archived instead of deleted.
+func archiveSchemaAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"status_attribute": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the status attribute that is set on archive. When object_type_id is set, it is an attribute of that object type.",
+			},
+			"status": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the status to set on archive, such as \"Retired\".",
+			},
+			"object_type_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the object type to move the object to on archive.",
+			},
+		},
+	}
+}
+
+// archiveObject sets the archive status of the object and moves it to the
+// archive object type, if one is configured.
+func archiveObject(ctx context.Context, client *assets.Client, metadata *metadataCache, workspaceId, objectId, typeId string, archive *objectArchiveModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if archive == nil {
+		diags.AddError(
+			"Missing Archive Configuration",
+			"The archive deletion policy requires the archive attribute to be set.",
+		)
+		return diags
+	}
+
+	// the status is set as part of the move, so it must be an attribute of
+	// the object type the object ends up with
+	objectTypeId := typeId
+	if !archive.ObjectTypeId.IsNull() {
+		objectTypeId = archive.ObjectTypeId.ValueString()
+	}
+
+	definitions, err := metadata.ObjectTypeAttributes(ctx, workspaceId, objectTypeId)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Object Type Attributes",
+			err.Error(),
+		)
+		return diags
+	}
+
+	definition := findAttributeByName(definitions, archive.StatusAttribute.ValueString())
+	if definition == nil || definition.Type != attributeTypeStatus {
+		diags.AddError(
+			"Invalid Archive Status Attribute",
+			fmt.Sprintf("Object type %s has no status attribute named %q.", objectTypeId, archive.StatusAttribute.ValueString()),
+		)
+		return diags
+	}
+
+	resolver := attributeValueResolver{
+		metadata:     metadata,
+		workspaceId:  workspaceId,
+		objectTypeId: objectTypeId,
+	}
+
+	statusId, err := resolver.statusId(ctx, archive.Status.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Unable to Resolve Archive Status",
+			err.Error(),
+		)
+		return diags
+	}
+
+	payload := &objectUpdatePayload{
+		ObjectTypeID: objectTypeId,
+		Attributes: []*objectUpdateAttribute{
+			{
+				ObjectTypeAttributeID: definition.ID,
+				ObjectAttributeValues: []*models.ObjectPayloadAttributeValueScheme{{Value: statusId}},
+			},
+		},
+	}
+
+	tflog.Info(ctx, "Archiving object.", map[string]interface{}{
+		"Id":             objectId,
+		"status":         archive.Status.ValueString(),
+		"object_type_id": objectTypeId,
+	})
+
+	_, response, err := updateObject(ctx, client, workspaceId, objectId, payload)
+	if isNotFound(response) {
+		return diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Error during object archiving",
+			wrapAPIError(response, err).Error(),
+		)
+	}
+
+	return diags
+}
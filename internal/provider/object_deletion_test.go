@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestArchiveObjectUsesTargetObjectType(t *testing.T) {
+	var updated objectUpdatePayload
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		// only the archive object type has the status attribute
+		case strings.HasSuffix(r.URL.Path, "/objecttype/117/attributes"):
+			_, _ = w.Write([]byte(`[{"id":"1087","name":"Name","type":0}]`))
+		case strings.HasSuffix(r.URL.Path, "/objecttype/200/attributes"):
+			_, _ = w.Write([]byte(`[{"id":"2001","name":"Lifecycle","type":7}]`))
+		case strings.HasSuffix(r.URL.Path, "/objecttype/200"):
+			_, _ = w.Write([]byte(`{"id":"200","objectSchemaId":"5"}`))
+		case strings.HasSuffix(r.URL.Path, "/config/statustype"):
+			_, _ = w.Write([]byte(`[{"id":"9","name":"Retired"}]`))
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/object/42"):
+			if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+				t.Error(err)
+			}
+			_, _ = w.Write([]byte(`{"id":"42"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := assets.New(nil, server.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	archive := &objectArchiveModel{
+		StatusAttribute: types.StringValue("Lifecycle"),
+		Status:          types.StringValue("Retired"),
+		ObjectTypeId:    types.StringValue("200"),
+	}
+
+	diags := archiveObject(context.Background(), client, newMetadataCache(client, nil), "ws", "42", "117", archive)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if updated.ObjectTypeID != "200" {
+		t.Errorf("expected the object to move to object type 200, got %q", updated.ObjectTypeID)
+	}
+
+	if len(updated.Attributes) != 1 || updated.Attributes[0].ObjectTypeAttributeID != "2001" || updated.Attributes[0].ObjectAttributeValues[0].Value != "9" {
+		t.Errorf("expected status 9 on attribute 2001, got %+v", updated.Attributes)
+	}
+}
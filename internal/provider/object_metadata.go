@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// resolveObjectTypeId returns the ID of the object type with the given name in
+// the object schema with the given key.
+func resolveObjectTypeId(ctx context.Context, metadata *metadataCache, workspaceId, objectSchemaKey, objectTypeName string) (string, error) {
+	schemas, err := metadata.ObjectSchemas(ctx, workspaceId)
+	if err != nil {
+		return "", err
+	}
+
+	var objectSchema *models.ObjectSchemaScheme
+	for _, s := range schemas {
+		if s.ObjectSchemaKey == objectSchemaKey {
+			objectSchema = s
+			break
+		}
+	}
+
+	if objectSchema == nil {
+		return "", fmt.Errorf("no object schema with key %q exists in workspace %s", objectSchemaKey, workspaceId)
+	}
+
+	objectTypes, err := metadata.ObjectTypes(ctx, workspaceId, objectSchema.Id)
+	if err != nil {
+		return "", err
+	}
+
+	for _, t := range objectTypes {
+		if t.Name == objectTypeName {
+			return t.Id, nil
+		}
+	}
+
+	return "", fmt.Errorf("no object type named %q exists in object schema %s", objectTypeName, objectSchemaKey)
+}
+
+// findAttributeByName returns the attribute definition with the given name, or nil.
+func findAttributeByName(definitions []*models.ObjectTypeAttributeScheme, name string) *models.ObjectTypeAttributeScheme {
+	for _, d := range definitions {
+		if d.Name == name {
+			return d
+		}
+	}
+
+	return nil
+}
+
+// findAttributeById returns the attribute definition with the given ID, or nil.
+func findAttributeById(definitions []*models.ObjectTypeAttributeScheme, id string) *models.ObjectTypeAttributeScheme {
+	for _, d := range definitions {
+		if d.ID == id {
+			return d
+		}
+	}
+
+	return nil
+}
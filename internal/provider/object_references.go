@@ -0,0 +1,453 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// errReferenceNotFound is returned when a referenced object does not exist,
+// which may only be until another resource of the same apply creates it.
+var errReferenceNotFound = errors.New("referenced object not found")
+
+// referenceRetryDelay is the delay before the first retry of resolving
+// references that were not found, doubling up to maxReferenceRetryDelay. The
+// total wait is limited to maxReferenceWait, so a misspelled object key fails
+// well before the create timeout.
+var (
+	referenceRetryDelay    = 2 * time.Second
+	maxReferenceRetryDelay = 30 * time.Second
+	maxReferenceWait       = 5 * time.Minute
+)
+
+// privateReferenceIds is the private state key of the IDs of the objects the
+// aql references selected when Terraform last read the object. The query may
+// match other objects by now, so conflicts are detected against these IDs.
+const privateReferenceIds = "reference_ids"
+
+// objectReferenceModel is a reference attribute of an object, pointing to
+// other objects by ID, by object key or by AQL query.
+type objectReferenceModel struct {
+	AttrTypeId types.String `tfsdk:"attr_type_id"`
+	ObjectIds  types.Set    `tfsdk:"object_ids"`
+	ObjectKeys types.Set    `tfsdk:"object_keys"`
+	Aql        types.String `tfsdk:"aql"`
+}
+
+// referencesBlock returns the schema of the references block.
+func referencesBlock() schema.SetNestedBlock {
+	return schema.SetNestedBlock{
+		Description: "Reference attributes of the object, pointing to other objects. Objects given by object_keys or aql " +
+			"are looked up during apply, so they add no dependency between resources and can refer to each other " +
+			"when two_phase_references is set.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"attr_type_id": schema.StringAttribute{
+					Required:    true,
+					Description: "The ID of the reference attribute type.",
+				},
+				"object_ids": schema.SetAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					Description: "The IDs of the referenced objects, such as the id of other jiraassets_object resources.",
+				},
+				"object_keys": schema.SetAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					Description: "The object keys of the referenced objects, such as ITSM-88.",
+				},
+				"aql": schema.StringAttribute{
+					Optional:    true,
+					Description: "An AQL query selecting the referenced objects, such as objectType = \"Racks\" AND Name = \"rack-1\".",
+				},
+			},
+		},
+	}
+}
+
+// referenceAttributeIds returns the IDs of the reference attributes of the object.
+func (m objectResourceModel) referenceAttributeIds() map[string]bool {
+	ids := map[string]bool{}
+	for _, reference := range m.References {
+		ids[reference.AttrTypeId.ValueString()] = true
+	}
+
+	return ids
+}
+
+// setStrings returns the elements of a set of strings, nil when it is null or unknown.
+func setStrings(ctx context.Context, set types.Set) ([]string, error) {
+	if set.IsNull() || set.IsUnknown() {
+		return nil, nil
+	}
+
+	var values []string
+	if diags := set.ElementsAs(ctx, &values, false); diags.HasError() {
+		return nil, fmt.Errorf("reading set of strings: %v", diags)
+	}
+
+	return values, nil
+}
+
+// resolve returns the sorted IDs of the referenced objects. Objects that do
+// not exist fail with errReferenceNotFound.
+func (m objectReferenceModel) resolve(ctx context.Context, client *assets.Client, workspaceId string) ([]string, error) {
+	ids, err := setStrings(ctx, m.ObjectIds)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := setStrings(ctx, m.ObjectKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) > 0 {
+		quoted := make([]string, 0, len(keys))
+		for _, key := range keys {
+			quoted = append(quoted, aqlString(key))
+		}
+
+		objects, err := listScopeObjects(ctx, client, workspaceId, "Key IN ("+strings.Join(quoted, ", ")+")")
+		if err != nil {
+			return nil, err
+		}
+
+		found := map[string]string{}
+		for _, object := range objects {
+			found[object.ObjectKey] = object.ID
+		}
+
+		var missing []string
+		for _, key := range keys {
+			id, ok := found[key]
+			if !ok {
+				missing = append(missing, key)
+				continue
+			}
+			ids = append(ids, id)
+		}
+
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			return nil, fmt.Errorf("%w: no object with key %s", errReferenceNotFound, strings.Join(missing, ", "))
+		}
+	}
+
+	if !m.Aql.IsNull() {
+		objects, err := listScopeObjects(ctx, client, workspaceId, m.Aql.ValueString())
+		if err != nil {
+			return nil, err
+		}
+
+		if len(objects) == 0 {
+			return nil, fmt.Errorf("%w: no object matches %s", errReferenceNotFound, m.Aql.ValueString())
+		}
+
+		for _, object := range objects {
+			ids = append(ids, object.ID)
+		}
+	}
+
+	unique := map[string]bool{}
+	for _, id := range ids {
+		unique[id] = true
+	}
+
+	resolved := make([]string, 0, len(unique))
+	for id := range unique {
+		resolved = append(resolved, id)
+	}
+	sort.Strings(resolved)
+
+	return resolved, nil
+}
+
+// waitForReferences calls resolve until the referenced objects are found,
+// waiting longer after each attempt for at most maxReferenceWait. Without
+// wait, or when resolve fails for another reason, the first result is returned.
+func waitForReferences(ctx context.Context, wait bool, resolve func(ctx context.Context) ([]string, error)) ([]string, error) {
+	if wait {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, maxReferenceWait)
+		defer cancel()
+	}
+
+	delay := referenceRetryDelay
+	for {
+		ids, err := resolve(ctx)
+		if err == nil || !wait || !errors.Is(err, errReferenceNotFound) {
+			return ids, err
+		}
+
+		tflog.Debug(ctx, "Referenced objects not found, retrying.", map[string]interface{}{
+			"error": err.Error(),
+			"delay": delay.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w, stopped waiting: %v", err, ctx.Err())
+		case <-time.After(delay):
+		}
+
+		if delay *= 2; delay > maxReferenceRetryDelay {
+			delay = maxReferenceRetryDelay
+		}
+	}
+}
+
+// referenceAttributes returns the payload attributes of the references of the
+// object. With wait, objects that are not found are looked up again for a
+// while, as they may be created by another resource of the apply.
+func (r *objectResource) referenceAttributes(ctx context.Context, workspaceId string, m objectResourceModel, wait bool) ([]*models.ObjectPayloadAttributeScheme, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var attributes []*models.ObjectPayloadAttributeScheme
+	for _, reference := range m.References {
+		ids, err := waitForReferences(ctx, wait, func(ctx context.Context) ([]string, error) {
+			return reference.resolve(ctx, r.client, workspaceId)
+		})
+		if err != nil {
+			diags.AddError(
+				"Unable to Resolve References",
+				fmt.Sprintf("Attribute %s: %s", reference.AttrTypeId.ValueString(), err),
+			)
+			continue
+		}
+
+		payloadValues := []*models.ObjectPayloadAttributeValueScheme{}
+		for _, id := range ids {
+			payloadValues = append(payloadValues, &models.ObjectPayloadAttributeValueScheme{Value: id})
+		}
+
+		attributes = append(attributes, &models.ObjectPayloadAttributeScheme{
+			ObjectTypeAttributeID: reference.AttrTypeId.ValueString(),
+			ObjectAttributeValues: payloadValues,
+		})
+	}
+
+	return attributes, diags
+}
+
+// refreshReference returns the reference as configured when the object still
+// references exactly the configured objects, and otherwise the IDs of the
+// objects it references. aqlIds are the IDs the aql query resolves to.
+func refreshReference(ctx context.Context, prior objectReferenceModel, values []*objectAttributeValue, aqlIds []string) (objectReferenceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	actual := map[string]bool{}
+	idsByKey := map[string]string{}
+	for _, value := range values {
+		actual[value.rawValue()] = true
+		if value.ReferencedObject != nil {
+			idsByKey[value.ReferencedObject.ObjectKey] = value.ReferencedObject.ID
+		}
+	}
+
+	ids, err := setStrings(ctx, prior.ObjectIds)
+	if err != nil {
+		diags.AddError("Unable to Read References", err.Error())
+		return prior, diags
+	}
+
+	keys, err := setStrings(ctx, prior.ObjectKeys)
+	if err != nil {
+		diags.AddError("Unable to Read References", err.Error())
+		return prior, diags
+	}
+
+	matches := true
+	expected := map[string]bool{}
+	for _, id := range append(ids, aqlIds...) {
+		expected[id] = true
+	}
+	for _, key := range keys {
+		id, ok := idsByKey[key]
+		if !ok {
+			matches = false
+			break
+		}
+		expected[id] = true
+	}
+
+	if matches && len(expected) == len(actual) {
+		for id := range expected {
+			if !actual[id] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return prior, diags
+		}
+	}
+
+	refreshed := make([]string, 0, len(actual))
+	for id := range actual {
+		refreshed = append(refreshed, id)
+	}
+	sort.Strings(refreshed)
+
+	objectIds, d := types.SetValueFrom(ctx, types.StringType, refreshed)
+	diags.Append(d...)
+
+	return objectReferenceModel{
+		AttrTypeId: prior.AttrTypeId,
+		ObjectIds:  objectIds,
+		ObjectKeys: types.SetNull(types.StringType),
+		Aql:        types.StringNull(),
+	}, diags
+}
+
+// refreshReferences returns the references of the object as returned by the API.
+func (r *objectResource) refreshReferences(ctx context.Context, workspaceId string, references []objectReferenceModel, attrs []*objectAttribute) ([]objectReferenceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// an empty block set is never null in the configuration
+	refreshed := make([]objectReferenceModel, 0, len(references))
+	for _, reference := range references {
+		var aqlIds []string
+		if !reference.Aql.IsNull() {
+			objects, err := listScopeObjects(ctx, r.client, workspaceId, reference.Aql.ValueString())
+			if err != nil {
+				diags.AddError(
+					"Unable to Resolve References",
+					fmt.Sprintf("Attribute %s: %s", reference.AttrTypeId.ValueString(), err),
+				)
+				return references, diags
+			}
+
+			for _, object := range objects {
+				aqlIds = append(aqlIds, object.ID)
+			}
+		}
+
+		value, d := refreshReference(ctx, reference, objectAttributeEntries(attrs, reference.AttrTypeId.ValueString()), aqlIds)
+		diags.Append(d...)
+
+		refreshed = append(refreshed, value)
+	}
+
+	return refreshed, diags
+}
+
+// aqlReferenceIds returns the IDs of the objects the aql references of the
+// object currently reference, by attribute type ID. Nil attributes could not
+// be read, so nothing is recorded.
+func aqlReferenceIds(references []objectReferenceModel, attrs []*objectAttribute) map[string][]string {
+	if attrs == nil {
+		return nil
+	}
+
+	ids := map[string][]string{}
+	for _, reference := range references {
+		if reference.Aql.IsNull() {
+			continue
+		}
+
+		id := reference.AttrTypeId.ValueString()
+		ids[id] = referencedIds(objectAttributeEntries(attrs, id))
+	}
+
+	return ids
+}
+
+// referencedIds returns the sorted IDs of the referenced objects.
+func referencedIds(values []*objectAttributeValue) []string {
+	ids := []string{}
+	for _, value := range values {
+		ids = append(ids, value.rawValue())
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// getReferenceIds returns the IDs of the objects the aql references selected
+// at the last read, recorded in the private state.
+func getReferenceIds(ctx context.Context, private privateStateReader) (map[string][]string, diag.Diagnostics) {
+	ids := map[string][]string{}
+
+	data, diags := private.GetKey(ctx, privateReferenceIds)
+	if diags.HasError() || len(data) == 0 {
+		return ids, diags
+	}
+
+	if err := json.Unmarshal(data, &ids); err != nil {
+		diags.AddError(
+			"Unable to Read Private State",
+			"The IDs of the referenced objects could not be decoded: "+err.Error(),
+		)
+	}
+
+	return ids, diags
+}
+
+// setReferenceIds records the IDs of the objects the aql references select in
+// the private state, removing the key when there are none.
+func setReferenceIds(ctx context.Context, private privateStateWriter, ids map[string][]string) diag.Diagnostics {
+	if len(ids) == 0 {
+		return private.SetKey(ctx, privateReferenceIds, nil)
+	}
+
+	data, err := json.Marshal(ids)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Unable to Write Private State",
+			"The IDs of the referenced objects could not be encoded: "+err.Error(),
+		)
+		return diags
+	}
+
+	return private.SetKey(ctx, privateReferenceIds, data)
+}
+
+// validateReference checks a reference of the references block against its
+// attribute definition.
+func validateReference(typeId string, definitions []*models.ObjectTypeAttributeScheme, attrPath path.Path, reference objectReferenceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	definition := findAttributeById(definitions, reference.AttrTypeId.ValueString())
+	if definition == nil {
+		diags.AddAttributeError(
+			attrPath,
+			"Unknown Attribute Type",
+			fmt.Sprintf("Object type %s has no attribute with ID %s.", typeId, reference.AttrTypeId.ValueString()),
+		)
+		return diags
+	}
+
+	if definition.Type != attributeTypeReference {
+		diags.AddAttributeError(
+			attrPath,
+			"Not a Reference Attribute",
+			fmt.Sprintf("Attribute %q is not a reference attribute, set it with attributes instead.", definition.Name),
+		)
+		return diags
+	}
+
+	diags.Append(validateEditable(attrPath, definition)...)
+
+	// the number of objects an AQL query selects is only known on apply
+	if !reference.Aql.IsNull() || reference.ObjectIds.IsUnknown() || reference.ObjectKeys.IsUnknown() {
+		return diags
+	}
+
+	diags.Append(validateCardinality(attrPath, definition, len(reference.ObjectIds.Elements())+len(reference.ObjectKeys.Elements()))...)
+
+	return diags
+}
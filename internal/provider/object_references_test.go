@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRefreshReference(t *testing.T) {
+	ctx := context.Background()
+
+	values := []*objectAttributeValue{
+		{ReferencedObject: &referencedObject{ID: "10", ObjectKey: "ITSM-10"}},
+		{ReferencedObject: &referencedObject{ID: "11", ObjectKey: "ITSM-11"}},
+	}
+
+	prior := objectReferenceModel{
+		AttrTypeId: types.StringValue("1100"),
+		ObjectIds:  types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10")}),
+		ObjectKeys: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("ITSM-11")}),
+		Aql:        types.StringNull(),
+	}
+
+	refreshed, diags := refreshReference(ctx, prior, values, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if !reflect.DeepEqual(refreshed, prior) {
+		t.Errorf("expected the configured reference to be kept, got %v", refreshed)
+	}
+
+	refreshed, diags = refreshReference(ctx, prior, values[:1], nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if !refreshed.ObjectKeys.IsNull() || len(refreshed.ObjectIds.Elements()) != 1 {
+		t.Errorf("expected the referenced object IDs, got %v", refreshed)
+	}
+
+	aql := objectReferenceModel{
+		AttrTypeId: types.StringValue("1100"),
+		ObjectIds:  types.SetNull(types.StringType),
+		ObjectKeys: types.SetNull(types.StringType),
+		Aql:        types.StringValue(`Name = "rack-1"`),
+	}
+
+	refreshed, _ = refreshReference(ctx, aql, values, []string{"11", "10"})
+	if !reflect.DeepEqual(refreshed, aql) {
+		t.Errorf("expected the aql reference to be kept, got %v", refreshed)
+	}
+}
+
+func TestWaitForReferences(t *testing.T) {
+	referenceRetryDelay = time.Millisecond
+	defer func() { referenceRetryDelay = 2 * time.Second }()
+
+	ctx := context.Background()
+
+	attempts := 0
+	resolve := func(context.Context) ([]string, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, fmt.Errorf("%w: no object with key ITSM-1", errReferenceNotFound)
+		}
+		return []string{"1"}, nil
+	}
+
+	if _, err := waitForReferences(ctx, false, resolve); !errors.Is(err, errReferenceNotFound) || attempts != 1 {
+		t.Errorf("expected a single failed attempt without wait, got %d attempts: %v", attempts, err)
+	}
+
+	attempts = 0
+	if ids, err := waitForReferences(ctx, true, resolve); err != nil || attempts != 3 || !reflect.DeepEqual(ids, []string{"1"}) {
+		t.Errorf("expected the references after 3 attempts, got %v after %d attempts: %v", ids, attempts, err)
+	}
+
+	attempts = 0
+	failed := func(context.Context) ([]string, error) {
+		attempts++
+		return nil, errors.New("unauthorized")
+	}
+	if _, err := waitForReferences(ctx, true, failed); err == nil || attempts != 1 {
+		t.Errorf("expected other errors not to be retried, got %d attempts: %v", attempts, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+
+	missing := func(context.Context) ([]string, error) {
+		return nil, errReferenceNotFound
+	}
+	if _, err := waitForReferences(ctx, true, missing); !errors.Is(err, errReferenceNotFound) {
+		t.Errorf("expected the wait to end with the timeout, got %v", err)
+	}
+
+	// the wait is limited even when the operation timeout is longer
+	maxReferenceWait = 20 * time.Millisecond
+	defer func() { maxReferenceWait = 5 * time.Minute }()
+
+	started := time.Now()
+	if _, err := waitForReferences(context.Background(), true, missing); !errors.Is(err, errReferenceNotFound) {
+		t.Errorf("expected the wait to end after maxReferenceWait, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("expected the wait to end after maxReferenceWait, waited %s", elapsed)
+	}
+}
+
+func TestValidateReference(t *testing.T) {
+	definitions := []*models.ObjectTypeAttributeScheme{
+		{ID: "2", Name: "Name", Editable: true},
+		{ID: "1100", Name: "Rack", Editable: true, Type: attributeTypeReference, MaximumCardinality: 1},
+	}
+
+	ids := func(values ...string) types.Set {
+		var elements []attr.Value
+		for _, v := range values {
+			elements = append(elements, types.StringValue(v))
+		}
+		return types.SetValueMust(types.StringType, elements)
+	}
+
+	testCases := map[string]struct {
+		reference objectReferenceModel
+		expected  []string
+	}{
+		"valid":             {objectReferenceModel{AttrTypeId: types.StringValue("1100"), ObjectIds: ids("10"), ObjectKeys: types.SetNull(types.StringType)}, nil},
+		"unknown attribute": {objectReferenceModel{AttrTypeId: types.StringValue("9"), ObjectIds: ids("10"), ObjectKeys: types.SetNull(types.StringType)}, []string{"Unknown Attribute Type"}},
+		"not a reference":   {objectReferenceModel{AttrTypeId: types.StringValue("2"), ObjectIds: ids("10"), ObjectKeys: types.SetNull(types.StringType)}, []string{"Not a Reference Attribute"}},
+		"too many objects":  {objectReferenceModel{AttrTypeId: types.StringValue("1100"), ObjectIds: ids("10"), ObjectKeys: ids("ITSM-11")}, []string{"Too Many Attribute Values"}},
+		"aql":               {objectReferenceModel{AttrTypeId: types.StringValue("1100"), ObjectIds: ids("10"), ObjectKeys: ids("ITSM-11"), Aql: types.StringValue(`Name = "rack-1"`)}, nil},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diags := validateReference("117", definitions, path.Root("references"), testCase.reference)
+
+			var summaries []string
+			for _, d := range diags {
+				summaries = append(summaries, d.Summary())
+			}
+
+			if !reflect.DeepEqual(summaries, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, summaries)
+			}
+		})
+	}
+}
+
+func TestAccJiraAssetsObjectResource_references(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Reference another managed object by ID
+			{
+				Config: `resource "jiraassets_object" "rack" {
+					type_id = "118"
+					attributes = [
+						{
+							attr_type_id = "1097"
+							attr_value   = "rack-1"
+						},
+					]
+				}
+
+				resource "jiraassets_object" "server" {
+					type_id = "119"
+					attributes = [
+						{
+							attr_type_id = "1098"
+							attr_value   = "server-1"
+						},
+					]
+
+					references {
+						attr_type_id = "1099"
+						object_ids   = [jiraassets_object.rack.id]
+					}
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jiraassets_object.server", "references.#", "1"),
+					resource.TestCheckResourceAttrPair("jiraassets_object.server", "references.0.object_ids.0", "jiraassets_object.rack", "id"),
+				),
+			},
+			// Objects referring to each other through aql
+			{
+				Config: `resource "jiraassets_object" "primary" {
+					type_id              = "119"
+					two_phase_references = true
+					attributes = [
+						{
+							attr_type_id = "1098"
+							attr_value   = "server-primary"
+						},
+					]
+
+					references {
+						attr_type_id = "1100"
+						aql          = "objectTypeId = 119 AND Name = \"server-standby\""
+					}
+				}
+
+				resource "jiraassets_object" "standby" {
+					type_id              = "119"
+					two_phase_references = true
+					attributes = [
+						{
+							attr_type_id = "1098"
+							attr_value   = "server-standby"
+						},
+					]
+
+					references {
+						attr_type_id = "1100"
+						aql          = "objectTypeId = 119 AND Name = \"server-primary\""
+					}
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jiraassets_object.primary", "references.#", "1"),
+					resource.TestCheckResourceAttr("jiraassets_object.standby", "references.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccJiraAssetsObjectResource_invalidReference(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "jiraassets_object" "test" {
+					type_id = "119"
+					attributes = [
+						{
+							attr_type_id = "1098"
+							attr_value   = "server-1"
+						},
+					]
+
+					references {
+						attr_type_id = "1099"
+					}
+				}`,
+				ExpectError: regexp.MustCompile("Missing Referenced Objects"),
+			},
+		},
+	})
+}
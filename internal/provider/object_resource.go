@@ -3,15 +3,21 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/ctreminiom/go-atlassian/assets"
 	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -22,6 +28,9 @@ var (
 	_ resource.Resource                = &objectResource{}
 	_ resource.ResourceWithConfigure   = &objectResource{}
 	_ resource.ResourceWithImportState = &objectResource{}
+
+	_ resource.ResourceWithValidateConfig = &objectResource{}
+	_ resource.ResourceWithModifyPlan     = &objectResource{}
 )
 
 // NewObjectResource is a helper function to simplify the provider implementation.
@@ -33,6 +42,8 @@ func NewObjectResource() resource.Resource {
 type objectResource struct {
 	client       *assets.Client
 	workspace_id string
+	metadata     *metadataCache
+	redactor     *redactor
 }
 
 // Metadata returns the resource type name.
@@ -58,26 +69,80 @@ type objectResourceModel struct {
 	Updated     types.String `tfsdk:"updated"`
 	HasAvatar   types.Bool   `tfsdk:"has_avatar"`
 
-	TypeId     types.String              `tfsdk:"type_id"`
-	Attributes []objectAttrResourceModel `tfsdk:"attributes"`
-	AvatarUuid types.String              `tfsdk:"avatar_uuid"`
+	TypeId          types.String              `tfsdk:"type_id"`
+	ObjectTypeName  types.String              `tfsdk:"object_type_name"`
+	ObjectSchemaKey types.String              `tfsdk:"object_schema_key"`
+	Attributes      []objectAttrResourceModel `tfsdk:"attributes"`
+	AttributeValues types.Map                 `tfsdk:"attribute_values"`
+	AttributeIds    types.Map                 `tfsdk:"attribute_ids"`
+	AvatarUuid      types.String              `tfsdk:"avatar_uuid"`
+
+	AttributeManagement types.String `tfsdk:"attribute_management"`
+	AllAttributes       types.Map    `tfsdk:"all_attributes"`
+
+	AdoptExisting types.Bool `tfsdk:"adopt_existing"`
+	MatchOn       types.List `tfsdk:"match_on"`
+
+	DeletionPolicy types.String        `tfsdk:"deletion_policy"`
+	Archive        *objectArchiveModel `tfsdk:"archive"`
+
+	TypeChange       types.String `tfsdk:"type_change"`
+	AttributeMapping types.Map    `tfsdk:"attribute_mapping"`
+
+	SensitiveAttributes []objectSensitiveAttrModel `tfsdk:"sensitive_attributes"`
+
+	AttrValuesWo        types.Map   `tfsdk:"attr_values_wo"`
+	AttrValuesWoVersion types.Int64 `tfsdk:"attr_values_wo_version"`
+
+	References         []objectReferenceModel `tfsdk:"references"`
+	TwoPhaseReferences types.Bool             `tfsdk:"two_phase_references"`
+
+	ConflictDetection types.Bool `tfsdk:"conflict_detection"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type objectAttrResourceModel struct {
 	AttrTypeId types.String `tfsdk:"attr_type_id"`
 	AttrValue  types.String `tfsdk:"attr_value"`
+	AttrValues types.List   `tfsdk:"attr_values"`
+
+	ReferenceObjectKey types.String  `tfsdk:"reference_object_key"`
+	UserEmail          types.String  `tfsdk:"user_email"`
+	AccountId          types.String  `tfsdk:"account_id"`
+	GroupName          types.String  `tfsdk:"group_name"`
+	DateValue          dateValue     `tfsdk:"date_value"`
+	BoolValue          types.Bool    `tfsdk:"bool_value"`
+	NumberValue        types.Float64 `tfsdk:"number_value"`
+	SelectValue        types.String  `tfsdk:"select_value"`
+	StatusName         types.String  `tfsdk:"status_name"`
+}
+
+// values returns the configured values of the attribute, whether it is set
+// with attr_value or attr_values.
+func (m objectAttrResourceModel) values(ctx context.Context) ([]string, diag.Diagnostics) {
+	if m.AttrValues.IsNull() {
+		return []string{m.AttrValue.ValueString()}, nil
+	}
+
+	var values []string
+	diags := m.AttrValues.ElementsAs(ctx, &values, false)
+
+	return values, diags
 }
 
 // Schema defines the schema for the resource.
-func (r *objectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *objectResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "A Jira Assets object resource.",
 		Attributes: map[string]schema.Attribute{
 			"workspace_id": schema.StringAttribute{
+				Optional:    true,
 				Computed:    true,
-				Description: "The ID of the workspace the object belongs to.",
+				Description: "The ID of the workspace the object belongs to. Defaults to the provider workspace_id.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"global_id": schema.StringAttribute{
@@ -109,10 +174,86 @@ func (r *objectResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"type_id": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the object type. Either type_id or object_type_name and object_schema_key must be set.",
+			},
+			"type_change": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(typeChangeReplace),
+				Description: "What happens when the object type changes. \"replace\" replaces the object. \"move\" updates the object type in place, " +
+					"when both object types belong to the same object schema and Assets accepts the change, and the plan warns about attribute values that are lost. " +
+					"Defaults to \"replace\".",
+			},
+			"attribute_mapping": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Attribute type IDs of the prior object type mapped to attribute type IDs of the new object type, whose values are carried over when the object is moved.",
+			},
+			"object_type_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the object type, resolved to type_id at plan time. Requires object_schema_key.",
+			},
+			"object_schema_key": schema.StringAttribute{
+				Optional:    true,
+				Description: "The key of the object schema that object_type_name belongs to.",
+			},
+			"attribute_values": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Attribute values keyed by attribute name, resolved to attribute type IDs at plan time.",
+			},
+			"attribute_ids": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The attribute type IDs that the attribute_values names were resolved to, keyed by attribute name.",
+			},
+			"attribute_management": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(attributeManagementPartial),
+				Description: "How Terraform owns the attributes of the object. With \"partial\" only the configured attributes are managed, " +
+					"and attributes removed from the configuration are cleared. With \"authoritative\" every other editable, non-system attribute is cleared too. " +
+					"Defaults to \"partial\".",
+			},
+			"all_attributes": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.ListType{ElemType: types.StringType},
+				Description: "The values of every attribute of the object, including attributes Terraform does not manage, keyed by attribute name.",
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional: true,
+				Description: "Adopt an existing object of the object type instead of creating a new one, when exactly one object matches the match_on attributes. " +
+					"The attributes of the adopted object are updated to the configured values, and with authoritative attribute_management " +
+					"its other attributes are cleared. Creation fails when several objects match.",
+			},
+			"match_on": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The names of the attributes whose configured values identify the existing object to adopt. Required when adopt_existing is set.",
+			},
+			"deletion_policy": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(deletionPolicyDelete),
+				Description: "What happens to the object when the resource is destroyed. \"delete\" deletes the object, " +
+					"\"archive\" sets the status configured in archive and keeps the object, \"abandon\" keeps the object unchanged. Defaults to \"delete\".",
+			},
+			"archive": archiveSchemaAttribute("How the archive deletion policy archives the object. Required when deletion_policy is \"archive\"."),
+			"conflict_detection": schema.BoolAttribute{
+				Optional: true,
+				Description: "Read the object again before updating it, and fail instead of overwriting when it was updated since Terraform last read it " +
+					"and a managed attribute no longer holds the value in state. The error lists the conflicting attributes.",
+			},
+			"two_phase_references": schema.BoolAttribute{
+				Optional: true,
+				Description: "Create the object without its references first and set them once the object exists, waiting up to 5 minutes until the referenced objects exist. " +
+					"This allows objects to refer to each other through object_keys or aql, as each one is created before it waits for the others. " +
+					"Updates do not wait, references to objects that do not exist fail at once.",
 			},
 			"attributes": schema.SetNestedAttribute{
-				Required:    true,
+				Optional:    true,
 				Description: "The definition of the attribute that is associated with an object type",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -122,11 +263,84 @@ func (r *objectResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 						},
 						"attr_value": schema.StringAttribute{
 							Description: "The actual values of the object attribute. The size of the values array is determined by the cardinality constraints on the object type attribute as well as how many values are associated with the object attribute",
+							Optional:    true,
+						},
+						"attr_values": schema.ListAttribute{
+							Description: "All values of an attribute with a maximum cardinality above one. Exactly one of attr_value, attr_values or a typed value must be set. " +
+								"The order of the values is kept as configured as long as the object holds the same values.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"reference_object_key": schema.StringAttribute{
+							Description: "The key of the object referenced by an object reference attribute, resolved to the object ID.",
+							Optional:    true,
+						},
+						"user_email": schema.StringAttribute{
+							Description: "The email address of the user of a user attribute, resolved to the account ID. Requires the provider site_url.",
+							Optional:    true,
+						},
+						"account_id": schema.StringAttribute{
+							Description: "The account ID of the user of a user attribute.",
+							Optional:    true,
+						},
+						"group_name": schema.StringAttribute{
+							Description: "The name of the group of a group attribute.",
+							Optional:    true,
+						},
+						"date_value": schema.StringAttribute{
+							Description: "The value of a Date or DateTime attribute as an RFC3339 timestamp. Date attributes only keep the date.",
+							Optional:    true,
+							CustomType:  dateValueType{},
+						},
+						"bool_value": schema.BoolAttribute{
+							Description: "The value of a Boolean attribute.",
+							Optional:    true,
+						},
+						"number_value": schema.Float64Attribute{
+							Description: "The value of an Integer or Double attribute.",
+							Optional:    true,
+						},
+						"select_value": schema.StringAttribute{
+							Description: "The option of a Select attribute.",
+							Optional:    true,
+						},
+						"status_name": schema.StringAttribute{
+							Description: "The name of the status of a status attribute, resolved to the status ID.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"sensitive_attributes": schema.SetNestedAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Description: "Attributes whose values are secrets, such as passwords or license keys. " +
+					"Their values are shown as sensitive in plans, masked in the provider logs when at least 6 characters long, and left out of all_attributes.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"attr_type_id": schema.StringAttribute{
+							Description: "The ID of the attribute type.",
+							Required:    true,
+						},
+						"attr_value": schema.StringAttribute{
+							Description: "The value of the attribute.",
 							Required:    true,
 						},
 					},
 				},
 			},
+			"attr_values_wo": schema.MapAttribute{
+				Optional:    true,
+				WriteOnly:   true,
+				ElementType: types.ListType{ElemType: types.StringType},
+				Description: "Values of attributes by attribute type ID that are sent to Assets but never stored in the plan or state. " +
+					"They are sent when the object is created and whenever attr_values_wo_version changes, and are not checked for drift. " +
+					"Requires Terraform 1.11 or later.",
+			},
+			"attr_values_wo_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The version of attr_values_wo. Change it to send the write-only values again.",
+			},
 			"created": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
@@ -146,257 +360,1273 @@ func (r *objectResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Description: "The UUID as retrieved by uploading an avatar.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"references": referencesBlock(),
+			"timeouts":   timeoutsBlock(ctx),
+		},
 	}
 }
 
-// Create creates the resource and sets the initial Terraform state.
-func (r *objectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	// Retrieve values from plan
-	var plan objectResourceModel
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
+// ValidateConfig checks that the object type and at least one attribute are configured.
+func (r *objectResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// read attributes individually, the configuration may contain unknown values at this point
+	var typeId, objectTypeName, objectSchemaKey, attributeManagement types.String
+	var attributes, sensitiveAttributes, references types.Set
+	var attributeValues, attrValuesWo types.Map
+	var attrValuesWoVersion types.Int64
+	var adoptExisting types.Bool
+	var matchOn types.List
+	var deletionPolicy, typeChange types.String
+	var archive types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type_id"), &typeId)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("object_type_name"), &objectTypeName)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("object_schema_key"), &objectSchemaKey)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("attributes"), &attributes)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sensitive_attributes"), &sensitiveAttributes)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("references"), &references)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("attribute_values"), &attributeValues)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("attribute_management"), &attributeManagement)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("adopt_existing"), &adoptExisting)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("match_on"), &matchOn)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deletion_policy"), &deletionPolicy)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("archive"), &archive)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type_change"), &typeChange)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("attr_values_wo"), &attrValuesWo)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("attr_values_wo_version"), &attrValuesWoVersion)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var attributes []*models.ObjectPayloadAttributeScheme
-	for _, attr := range plan.Attributes {
-		attributes = append(attributes, &models.ObjectPayloadAttributeScheme{
-			ObjectTypeAttributeID: attr.AttrTypeId.ValueString(),
-			ObjectAttributeValues: []*models.ObjectPayloadAttributeValueScheme{
-				{
-					Value: attr.AttrValue.ValueString(),
-				},
-			},
-		})
+	switch attributeManagement.ValueString() {
+	case "", attributeManagementPartial, attributeManagementAuthoritative:
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("attribute_management"),
+			"Invalid Attribute Management",
+			fmt.Sprintf("attribute_management must be %q or %q. Got: %q", attributeManagementPartial, attributeManagementAuthoritative, attributeManagement.ValueString()),
+		)
 	}
 
-	// create payload
-	payload := &models.ObjectPayloadScheme{
-		ObjectTypeID: plan.TypeId.ValueString(),
-		Attributes:   attributes,
-		HasAvatar:    plan.HasAvatar.ValueBool(),
-		AvatarUUID:   plan.AvatarUuid.ValueString(),
+	if typeId.IsNull() == objectTypeName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type_id"),
+			"Invalid Object Type Configuration",
+			"Exactly one of type_id or object_type_name must be set.",
+		)
 	}
 
-	object, response, err := r.client.Object.Create(ctx, r.workspace_id, payload)
-	if err != nil {
-		if response != nil {
-			tflog.Error(ctx, "Error creating object: %s", map[string]interface{}{
-				"url":         response.Request.URL,
-				"status_code": response.StatusCode,
-				"headers":     response.Header,
-				"body":        response.Body,
-			})
-		}
+	if !objectTypeName.IsNull() && objectSchemaKey.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("object_schema_key"),
+			"Missing Object Schema Key",
+			"object_schema_key must be set when the object type is given by object_type_name.",
+		)
+	}
 
-		resp.Diagnostics.AddError(
-			"Error during object creation",
-			err.Error(),
+	switch typeChange.ValueString() {
+	case "", typeChangeReplace, typeChangeMove:
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type_change"),
+			"Invalid Type Change",
+			fmt.Sprintf("type_change must be %q or %q. Got: %q", typeChangeReplace, typeChangeMove, typeChange.ValueString()),
 		)
-		return
 	}
 
-	// Map response body to schema and populate Computed attributes
-	plan.WorkspaceId = types.StringValue(object.WorkspaceId)
-	plan.GlobalId = types.StringValue(object.GlobalId)
-	plan.Id = types.StringValue(object.ID)
-	plan.Label = types.StringValue(object.Label)
-	plan.ObjectKey = types.StringValue(object.ObjectKey)
-	plan.Created = types.StringValue(object.Created)
-	plan.Updated = types.StringValue(object.Updated)
-	plan.HasAvatar = types.BoolValue(object.HasAvatar)
+	switch deletionPolicy.ValueString() {
+	case "", deletionPolicyDelete, deletionPolicyAbandon:
+	case deletionPolicyArchive:
+		if archive.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("archive"),
+				"Missing Archive Configuration",
+				"archive must be set when deletion_policy is \"archive\".",
+			)
+		}
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deletion_policy"),
+			"Invalid Deletion Policy",
+			fmt.Sprintf("deletion_policy must be %q, %q or %q. Got: %q", deletionPolicyDelete, deletionPolicyArchive, deletionPolicyAbandon, deletionPolicy.ValueString()),
+		)
+	}
 
-	// Set state to full populated data
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	if adoptExisting.ValueBool() && !matchOn.IsUnknown() && len(matchOn.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("match_on"),
+			"Missing Match Attributes",
+			"match_on must list at least one attribute name when adopt_existing is set.",
+		)
 	}
-}
 
-// Read refreshes the Terraform state with the latest data.
-func (r *objectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	// Get current state
-	var state objectResourceModel
-	diags := req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	if attributes.IsNull() && attributeValues.IsNull() && sensitiveAttributes.IsNull() && attrValuesWo.IsNull() && len(references.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("attributes"),
+			"Missing Object Attributes",
+			"At least one of attributes, attribute_values, sensitive_attributes, attr_values_wo or references must be set.",
+		)
 	}
 
-	// Get refreshed object from Assets API
-	object, response, err := r.client.Object.Get(ctx, r.workspace_id, state.Id.ValueString())
-	if err != nil {
-		if response != nil {
-			tflog.Error(ctx, "Error reading object: %s", map[string]interface{}{
-				"url":         response.Request.URL,
-				"status_code": response.StatusCode,
-				"headers":     response.Header,
-				"body":        response.Body,
-			})
+	for _, element := range references.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsUnknown() {
+			continue
 		}
 
-		resp.Diagnostics.AddError(
-			"Error during object reading",
-			err.Error(),
-		)
-		return
-	}
+		set := 0
+		for _, field := range []string{"object_ids", "object_keys", "aql"} {
+			if !object.Attributes()[field].IsNull() {
+				set++
+			}
+		}
 
-	// Get refreshed object attributes from Assets API
-	attrs, response, err := r.client.Object.Attributes(ctx, r.workspace_id, state.Id.ValueString())
-	if err != nil {
-		if response != nil {
-			tflog.Error(ctx, "Error reading object attributes: %s", map[string]interface{}{
-				"url":         response.Request.URL,
-				"status_code": response.StatusCode,
-				"headers":     response.Header,
-				"body":        response.Body,
-			})
+		if set == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("references").AtSetValue(element),
+				"Missing Referenced Objects",
+				"At least one of object_ids, object_keys or aql must be set.",
+			)
 		}
-		resp.Diagnostics.AddError(
-			"Error during object attributes reading",
-			err.Error(),
+	}
+
+	// without a version the write-only values could never be sent again
+	if !attrValuesWo.IsNull() && attrValuesWoVersion.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("attr_values_wo_version"),
+			"Missing Write-Only Version",
+			"attr_values_wo_version must be set when attr_values_wo is set.",
 		)
-		return
 	}
 
-	var attributes []objectAttrResourceModel
-	for _, attr := range attrs {
-		// only map known attributes in the state, this is because the API return computed attributes like "key", "created",
-		// and "updated". we don't know the type id of those attributes, so we can't exclude them specifically
+	for _, element := range attributes.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsUnknown() {
+			continue
+		}
+
+		set := 0
+		for _, field := range attributeValueFields {
+			if !object.Attributes()[field].IsNull() {
+				set++
+			}
+		}
 
-		for i := range state.Attributes {
-			if state.Attributes[i].AttrTypeId == types.StringValue(attr.ObjectTypeAttributeId) {
-				attributes = append(attributes, objectAttrResourceModel{
-					AttrTypeId: types.StringValue(attr.ObjectTypeAttributeId),
-					AttrValue:  types.StringValue(attr.ObjectAttributeValues[0].Value),
-				})
+		if set != 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("attributes").AtSetValue(element),
+				"Invalid Attribute Value Configuration",
+				fmt.Sprintf("Exactly one of %s must be set.", strings.Join(attributeValueFields, ", ")),
+			)
+		}
+
+		if dateValue, ok := object.Attributes()["date_value"].(types.String); ok && !dateValue.IsNull() && !dateValue.IsUnknown() {
+			if _, err := time.Parse(time.RFC3339, dateValue.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("attributes").AtSetValue(element).AtName("date_value"),
+					"Invalid Date Value",
+					fmt.Sprintf("date_value must be an RFC3339 timestamp such as 2024-01-31T00:00:00Z. Got: %q", dateValue.ValueString()),
+				)
 			}
 		}
 	}
+}
 
-	// Overwrite items in state with refreshed values
-	state.Attributes = attributes
-	state.WorkspaceId = types.StringValue(object.WorkspaceId)
-	state.GlobalId = types.StringValue(object.GlobalId)
-	state.Id = types.StringValue(object.ID)
-	state.Label = types.StringValue(object.Label)
-	state.ObjectKey = types.StringValue(object.ObjectKey)
-	state.HasAvatar = types.BoolValue(object.HasAvatar)
+// ModifyPlan resolves the object type and attribute names to their IDs, so
+// both are known in the plan and stored in state, and validates the attributes
+// against the object type so invalid objects fail the plan instead of the apply.
+func (r *objectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to resolve when the object is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
 
-	diags = resp.State.Set(ctx, state)
+	ctx, done, diags := withPlanTimeout(ctx, req.Plan)
+	defer done(&resp.Diagnostics)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-}
 
-// Update updates the resource and sets the updated Terraform state on success.
-func (r *objectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Retrieve values from plan
-	var plan objectResourceModel
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
+	// the plan can only be decoded once the attribute set is known, which is
+	// at the latest when Terraform plans the change again during apply
+	var attributes, sensitiveAttributes, references types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("attributes"), &attributes)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("sensitive_attributes"), &sensitiveAttributes)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("references"), &references)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	// Generate API request body from plan
-	// if an attribute is removed from plan, it will not be removed from the object
-	// this is due to how the API only partially updates the object
-	var attributes []*models.ObjectPayloadAttributeScheme
-	for _, attr := range plan.Attributes {
-		attributes = append(attributes, &models.ObjectPayloadAttributeScheme{
-			ObjectTypeAttributeID: attr.AttrTypeId.ValueString(),
-			ObjectAttributeValues: []*models.ObjectPayloadAttributeValueScheme{
-				{
-					Value: attr.AttrValue.ValueString(),
-				},
-			},
-		})
+	if attributes.IsUnknown() || sensitiveAttributes.IsUnknown() || references.IsUnknown() {
+		// a changed object type still has to be planned
+		var typeId, workspaceId types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type_id"), &typeId)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("workspace_id"), &workspaceId)...)
+		if !resp.Diagnostics.HasError() && !typeId.IsUnknown() && !typeId.IsNull() {
+			r.planTypeChange(ctx, req, resp, workspaceIdOrDefault(workspaceId, r.workspace_id), typeId.ValueString())
+		}
+		return
 	}
 
-	// create payload
-	payload := &models.ObjectPayloadScheme{
-		ObjectTypeID: plan.TypeId.ValueString(),
-		Attributes:   attributes,
-		HasAvatar:    plan.HasAvatar.ValueBool(),
-		AvatarUUID:   plan.AvatarUuid.ValueString(),
+	var plan objectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// update object
-	tflog.Info(ctx, "Updating object.", map[string]interface{}{
-		"Id": plan.Id.ValueString(),
-	})
-	object, response, err := r.client.Object.Update(ctx, r.workspace_id, plan.Id.ValueString(), payload)
-	if err != nil {
-		if response != nil {
-			tflog.Error(ctx, "Error updating object: %s", map[string]interface{}{
-				"url":         response.Request.URL,
-				"status_code": response.StatusCode,
-				"headers":     response.Header,
-				"body":        response.Body,
-			})
+	workspaceId := workspaceIdOrDefault(plan.WorkspaceId, r.workspace_id)
+
+	if plan.TypeId.IsUnknown() && !plan.ObjectTypeName.IsUnknown() && !plan.ObjectSchemaKey.IsUnknown() && !plan.ObjectTypeName.IsNull() {
+		typeId, err := resolveObjectTypeId(ctx, r.metadata, workspaceId, plan.ObjectSchemaKey.ValueString(), plan.ObjectTypeName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("object_type_name"),
+				"Unable to Resolve Object Type",
+				err.Error(),
+			)
+			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Error during object update",
-			err.Error(),
-		)
-		return
+		plan.TypeId = types.StringValue(typeId)
 	}
 
-	// Update resource state with updated object and attributes
-	plan.WorkspaceId = types.StringValue(object.WorkspaceId)
-	plan.GlobalId = types.StringValue(object.GlobalId)
-	plan.Id = types.StringValue(object.ID)
-	plan.Label = types.StringValue(object.Label)
-	plan.ObjectKey = types.StringValue(object.ObjectKey)
-	plan.Created = types.StringValue(object.Created)
-	plan.Updated = types.StringValue(object.Updated)
-	plan.HasAvatar = types.BoolValue(object.HasAvatar)
+	// attribute definitions are needed to resolve names and validate values,
+	// neither is possible until the object type is known
+	if plan.TypeId.IsUnknown() {
+		if !plan.AttributeValues.IsNull() {
+			plan.AttributeIds = types.MapUnknown(types.StringType)
+		}
 
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
 		return
 	}
-}
 
-// Delete deletes the resource and removes the Terraform state on success.
-func (r *objectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// Retrieve values from state
-	var state objectResourceModel
-	diags := req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
+	r.planTypeChange(ctx, req, resp, workspaceId, plan.TypeId.ValueString())
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Delete existing object
-	response, err := r.client.Object.Delete(ctx, r.workspace_id, state.Id.ValueString())
+	definitions, err := r.metadata.ObjectTypeAttributes(ctx, workspaceId, plan.TypeId.ValueString())
 	if err != nil {
-		if response != nil {
-			tflog.Error(ctx, "Error deleting object: %s", map[string]interface{}{
-				"url":         response.Request.URL,
-				"status_code": response.StatusCode,
-				"headers":     response.Header,
-				"body":        response.Body,
-			})
-		}
-
 		resp.Diagnostics.AddError(
-			"Error during object deletion",
+			"Unable to Read Object Type Attributes",
 			err.Error(),
 		)
 		return
 	}
-}
 
-func (r *objectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	switch {
+	case plan.AttributeValues.IsNull():
+		plan.AttributeIds = types.MapNull(types.StringType)
+	case plan.AttributeValues.IsUnknown():
+		plan.AttributeIds = types.MapUnknown(types.StringType)
+	default:
+		attributeIds := map[string]attr.Value{}
+		for name := range plan.AttributeValues.Elements() {
+			definition := findAttributeByName(definitions, name)
+			if definition == nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("attribute_values").AtMapKey(name),
+					"Unknown Attribute Name",
+					fmt.Sprintf("Object type %s has no attribute named %q.", plan.TypeId.ValueString(), name),
+				)
+				continue
+			}
+
+			attributeIds[name] = types.StringValue(definition.ID)
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var diags diag.Diagnostics
+		plan.AttributeIds, diags = types.MapValue(types.StringType, attributeIds)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	// write-only values are null in the plan, they are validated from the configuration
+	var attrValuesWo types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("attr_values_wo"), &attrValuesWo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateObjectAttributes(ctx, plan.TypeId.ValueString(), definitions, attributes, sensitiveAttributes, references, plan.AttributeValues, plan.AttributeIds, attrValuesWo)...)
+
+	// match_on is only used when the object is created
+	if req.State.Raw.IsNull() && plan.AdoptExisting.ValueBool() && !plan.MatchOn.IsUnknown() {
+		for i, element := range plan.MatchOn.Elements() {
+			name, ok := element.(types.String)
+			if !ok || name.IsUnknown() || findAttributeByName(definitions, name.ValueString()) != nil {
+				continue
+			}
+
+			resp.Diagnostics.AddAttributeError(
+				path.Root("match_on").AtListIndex(i),
+				"Unknown Attribute Name",
+				fmt.Sprintf("Object type %s has no attribute named %q.", plan.TypeId.ValueString(), name.ValueString()),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// payloadAttributes returns the attributes to send to the API, combining the
+// attributes addressed by ID with those addressed by name. Typed values are
+// converted to the representation the API expects.
+func (r *objectResource) payloadAttributes(ctx context.Context, m objectResourceModel) ([]*models.ObjectPayloadAttributeScheme, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	workspaceId := workspaceIdOrDefault(m.WorkspaceId, r.workspace_id)
+
+	definitions, err := r.metadata.ObjectTypeAttributes(ctx, workspaceId, m.TypeId.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Object Type Attributes",
+			err.Error(),
+		)
+		return nil, diags
+	}
+
+	resolver := attributeValueResolver{
+		metadata:     r.metadata,
+		workspaceId:  workspaceId,
+		objectTypeId: m.TypeId.ValueString(),
+	}
+
+	configured := m.Attributes
+	for _, attr := range m.SensitiveAttributes {
+		configured = append(configured, attr.attribute())
+	}
+	sensitive := m.sensitiveAttributeIds()
+
+	var attributes []*models.ObjectPayloadAttributeScheme
+	for _, attr := range configured {
+		values, err := resolver.toAPI(ctx, findAttributeById(definitions, attr.AttrTypeId.ValueString()), attr)
+		if err != nil {
+			// lookup errors quote the value, such as the email address or object key
+			detail := err.Error()
+			if sensitive[attr.AttrTypeId.ValueString()] {
+				detail = "value (sensitive value) could not be resolved"
+			}
+
+			diags.AddError(
+				"Unable to Resolve Attribute Value",
+				fmt.Sprintf("Attribute %s: %s", attr.AttrTypeId.ValueString(), detail),
+			)
+			continue
+		}
+
+		var payloadValues []*models.ObjectPayloadAttributeValueScheme
+		for _, value := range values {
+			payloadValues = append(payloadValues, &models.ObjectPayloadAttributeValueScheme{
+				Value: value,
+			})
+		}
+
+		attributes = append(attributes, &models.ObjectPayloadAttributeScheme{
+			ObjectTypeAttributeID: attr.AttrTypeId.ValueString(),
+			ObjectAttributeValues: payloadValues,
+		})
+	}
+
+	if m.AttributeValues.IsNull() || diags.HasError() {
+		return attributes, diags
+	}
+
+	var attributeValues, attributeIds map[string]string
+	diags.Append(m.AttributeValues.ElementsAs(ctx, &attributeValues, false)...)
+	diags.Append(m.AttributeIds.ElementsAs(ctx, &attributeIds, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for name, value := range attributeValues {
+		attributes = append(attributes, &models.ObjectPayloadAttributeScheme{
+			ObjectTypeAttributeID: attributeIds[name],
+			ObjectAttributeValues: []*models.ObjectPayloadAttributeValueScheme{
+				{
+					Value: value,
+				},
+			},
+		})
+	}
+
+	return attributes, diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *objectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan objectResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, "create", createTimeout)
+	defer done(&resp.Diagnostics)
+
+	writeOnly, diags := writeOnlyValues(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.maskSensitiveValues(ctx, plan)
+	ctx = r.maskWriteOnlyValues(ctx, writeOnly)
+
+	attributes, diags := r.payloadAttributes(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	attributes = append(attributes, writeOnlyAttributes(writeOnly)...)
+
+	workspaceId := workspaceIdOrDefault(plan.WorkspaceId, r.workspace_id)
+
+	// with two-phase references the object is created without them, so
+	// objects referring to each other are all created before any waits
+	twoPhase := plan.TwoPhaseReferences.ValueBool() && len(plan.References) > 0
+	if !twoPhase {
+		references, diags := r.referenceAttributes(ctx, workspaceId, plan, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		attributes = append(attributes, references...)
+	}
+
+	// create payload
+	payload := &models.ObjectPayloadScheme{
+		ObjectTypeID: plan.TypeId.ValueString(),
+		Attributes:   attributes,
+		HasAvatar:    plan.HasAvatar.ValueBool(),
+		AvatarUUID:   plan.AvatarUuid.ValueString(),
+	}
+
+	existing, diags := r.findExistingObject(ctx, workspaceId, plan, attributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var object *models.ObjectScheme
+	var response *models.ResponseScheme
+	var err error
+	if existing != nil {
+		// with authoritative attribute management the adopted object keeps
+		// only the configured attributes
+		var cleared []string
+		if plan.isAuthoritative() {
+			planned, diags := plan.managedAttributeIds(ctx)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			for id := range writeOnly {
+				planned[id] = true
+			}
+
+			cleared, diags = r.unmanagedObjectAttributeIds(ctx, workspaceId, plan.TypeId.ValueString(), existing.ID, planned)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		tflog.Info(ctx, "Adopting existing object.", map[string]interface{}{
+			"Id":         existing.ID,
+			"object_key": existing.ObjectKey,
+			"cleared":    cleared,
+		})
+		object, response, err = updateObject(ctx, r.client, workspaceId, existing.ID, newObjectUpdatePayload(plan, attributes, cleared))
+	} else {
+		object, response, err = r.client.Object.Create(ctx, workspaceId, payload)
+	}
+	if err != nil {
+		r.logAPIError(ctx, "Error creating object.", response)
+
+		resp.Diagnostics.AddError(
+			"Error during object creation",
+			err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attributes
+	plan.WorkspaceId = types.StringValue(object.WorkspaceId)
+	plan.GlobalId = types.StringValue(object.GlobalId)
+	plan.Id = types.StringValue(object.ID)
+	plan.Label = types.StringValue(object.Label)
+	plan.ObjectKey = types.StringValue(object.ObjectKey)
+	plan.Created = types.StringValue(object.Created)
+	plan.Updated = types.StringValue(object.Updated)
+	plan.HasAvatar = types.BoolValue(object.HasAvatar)
+
+	if twoPhase {
+		resp.Diagnostics.Append(r.setReferences(ctx, workspaceId, &plan)...)
+	}
+
+	var attrs []*objectAttribute
+	plan.AllAttributes, attrs = r.readAllAttributes(ctx, workspaceId, plan, writeOnlyAttributeIds(writeOnly), &resp.Diagnostics)
+
+	resp.Diagnostics.Append(setWriteOnlyAttributeIds(ctx, resp.Private, writeOnlyAttributeIds(writeOnly))...)
+	resp.Diagnostics.Append(setReferenceIds(ctx, resp.Private, aqlReferenceIds(plan.References, attrs))...)
+
+	// Set state to full populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *objectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state objectResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, "read", readTimeout)
+	defer done(&resp.Diagnostics)
+
+	ctx = r.maskSensitiveValues(ctx, state)
+
+	// the values of write-only attributes are never read back
+	writeOnly, diags := getWriteOnlyAttributeIds(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workspaceId := workspaceIdOrDefault(state.WorkspaceId, r.workspace_id)
+
+	// Get refreshed object from Assets API
+	object, response, err := r.client.Object.Get(ctx, workspaceId, state.Id.ValueString())
+	if isNotFound(response) {
+		// the object was deleted outside of Terraform, plan to create it again
+		tflog.Warn(ctx, "Object no longer exists, removing it from state.", map[string]interface{}{
+			"Id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		r.logAPIError(ctx, "Error reading object.", response)
+
+		resp.Diagnostics.AddError(
+			"Error during object reading",
+			err.Error(),
+		)
+		return
+	}
+
+	// Get refreshed object attributes from Assets API
+	attrs, response, err := getObjectAttributes(ctx, r.client, workspaceId, state.Id.ValueString())
+	if isNotFound(response) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		r.logAPIError(ctx, "Error reading object attributes.", response)
+		resp.Diagnostics.AddError(
+			"Error during object attributes reading",
+			err.Error(),
+		)
+		return
+	}
+
+	// type_id is only missing from state after an import
+	imported := state.TypeId.IsNull()
+	if object.ObjectType != nil {
+		state.TypeId = types.StringValue(object.ObjectType.Id)
+	}
+
+	definitions, err := r.metadata.ObjectTypeAttributes(ctx, workspaceId, state.TypeId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Object Type Attributes",
+			err.Error(),
+		)
+		return
+	}
+
+	resolver := attributeValueResolver{
+		metadata:     r.metadata,
+		workspaceId:  workspaceId,
+		objectTypeId: state.TypeId.ValueString(),
+		definitions:  definitions,
+	}
+
+	// an imported object has no attributes in state yet, import every
+	// attribute that can be configured
+	if imported {
+		state.Attributes, diags = importedAttributes(ctx, definitions, attrs)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var attributes []objectAttrResourceModel
+	for _, stateAttr := range state.Attributes {
+		// only map known attributes in the state, this is because the API return computed attributes like "key", "created",
+		// and "updated". we don't know the type id of those attributes, so we can't exclude them specifically
+		refreshed, found, d := resolver.fromAPI(ctx, stateAttr, objectAttributeEntries(attrs, stateAttr.AttrTypeId.ValueString()))
+		resp.Diagnostics.Append(d...)
+
+		// an attribute without values no longer exists on the object
+		if !found {
+			continue
+		}
+
+		attributes = append(attributes, refreshed)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var sensitiveAttributes []objectSensitiveAttrModel
+	for _, stateAttr := range state.SensitiveAttributes {
+		refreshed, found, d := resolver.fromAPI(ctx, stateAttr.attribute(), objectAttributeEntries(attrs, stateAttr.AttrTypeId.ValueString()))
+		resp.Diagnostics.Append(d...)
+		if !found {
+			continue
+		}
+
+		sensitiveAttributes = append(sensitiveAttributes, objectSensitiveAttrModel{
+			AttrTypeId: refreshed.AttrTypeId,
+			AttrValue:  refreshed.AttrValue,
+		})
+	}
+	state.SensitiveAttributes = sensitiveAttributes
+
+	state.References, diags = r.refreshReferences(ctx, workspaceId, state.References, attrs)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setReferenceIds(ctx, resp.Private, aqlReferenceIds(state.References, attrs))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// with authoritative attribute management, attributes set outside of
+	// Terraform are drift that the next apply clears
+	if state.isAuthoritative() {
+		managed, d := state.managedAttributeIds(ctx)
+		resp.Diagnostics.Append(d...)
+		for id := range writeOnly {
+			managed[id] = true
+		}
+
+		unmanaged, d := unmanagedAttributes(ctx, definitions, attrs, managed)
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		attributes = append(attributes, unmanaged...)
+	}
+
+	state.AllAttributes, diags = allAttributesValue(ctx, definitions, attrs, state.hiddenAttributeIds(writeOnly))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// refresh the attributes addressed by name, dropping those no longer set on the object
+	if !state.AttributeValues.IsNull() {
+		var attributeIds map[string]string
+		resp.Diagnostics.Append(state.AttributeIds.ElementsAs(ctx, &attributeIds, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var priorValues map[string]string
+		resp.Diagnostics.Append(state.AttributeValues.ElementsAs(ctx, &priorValues, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		attributeValues := map[string]attr.Value{}
+		for name, prior := range priorValues {
+			values := objectAttributeEntries(attrs, attributeIds[name])
+			if len(values) == 0 {
+				continue
+			}
+
+			// keep the configured representation of an unchanged value
+			value := values[0].rawValue()
+			if matchesAttributeValue(findAttributeById(definitions, attributeIds[name]), prior, values[0]) {
+				value = prior
+			}
+
+			attributeValues[name] = types.StringValue(value)
+		}
+
+		state.AttributeValues, diags = types.MapValue(types.StringType, attributeValues)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Overwrite items in state with refreshed values
+	if state.AttributeManagement.IsNull() {
+		state.AttributeManagement = types.StringValue(attributeManagementPartial)
+	}
+	if state.DeletionPolicy.IsNull() {
+		state.DeletionPolicy = types.StringValue(deletionPolicyDelete)
+	}
+	if state.TypeChange.IsNull() {
+		state.TypeChange = types.StringValue(typeChangeReplace)
+	}
+	state.Attributes = attributes
+	state.WorkspaceId = types.StringValue(object.WorkspaceId)
+	state.GlobalId = types.StringValue(object.GlobalId)
+	state.Id = types.StringValue(object.ID)
+	state.Label = types.StringValue(object.Label)
+	state.ObjectKey = types.StringValue(object.ObjectKey)
+	state.Created = types.StringValue(object.Created)
+	state.Updated = types.StringValue(object.Updated)
+	state.HasAvatar = types.BoolValue(object.HasAvatar)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// objectAttributeEntries returns the values of the attribute with the given
+// type ID, in the order returned by the API.
+func objectAttributeEntries(attrs []*objectAttribute, attrTypeId string) []*objectAttributeValue {
+	var values []*objectAttributeValue
+	for _, a := range attrs {
+		if a.ObjectTypeAttributeId == attrTypeId {
+			values = append(values, a.ObjectAttributeValues...)
+		}
+	}
+
+	return values
+}
+
+// objectAttributeValues returns the raw values of the attribute with the given
+// type ID, in the order returned by the API.
+func objectAttributeValues(attrs []*objectAttribute, attrTypeId string) []string {
+	var values []string
+	for _, v := range objectAttributeEntries(attrs, attrTypeId) {
+		values = append(values, v.rawValue())
+	}
+
+	return values
+}
+
+// keepValueOrder returns the prior values when they hold the same values as
+// the refreshed ones, so a different order returned by the API is not a change.
+func keepValueOrder(prior, refreshed []string) []string {
+	if len(prior) != len(refreshed) {
+		return refreshed
+	}
+
+	counts := map[string]int{}
+	for _, v := range prior {
+		counts[v]++
+	}
+
+	for _, v := range refreshed {
+		if counts[v] == 0 {
+			return refreshed
+		}
+		counts[v]--
+	}
+
+	return prior
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *objectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan objectResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state objectResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, "update", updateTimeout)
+	defer done(&resp.Diagnostics)
+
+	writeOnly, diags := writeOnlyValues(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	priorWriteOnly, diags := getWriteOnlyAttributeIds(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.maskSensitiveValues(ctx, plan, state)
+	ctx = r.maskWriteOnlyValues(ctx, writeOnly)
+
+	// compare the object with the prior state before anything is written
+	if plan.ConflictDetection.ValueBool() {
+		referenceIds, diags := getReferenceIds(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(r.checkConflicts(ctx, workspaceIdOrDefault(state.WorkspaceId, r.workspace_id), state, referenceIds)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Generate API request body from plan
+	attributes, diags := r.payloadAttributes(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// write-only values are only sent again when their version changes, or
+	// when the object moves to another object type
+	if writeOnlyChanged(state, plan) || !state.TypeId.Equal(plan.TypeId) {
+		attributes = append(attributes, writeOnlyAttributes(writeOnly)...)
+	}
+
+	workspaceId := workspaceIdOrDefault(plan.WorkspaceId, r.workspace_id)
+
+	// only the second phase of creating an object waits for its references,
+	// an update fails at once so a misspelled object key does not block it
+	references, diags := r.referenceAttributes(ctx, workspaceId, plan, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	attributes = append(attributes, references...)
+
+	var cleared []string
+	if state.TypeId.Equal(plan.TypeId) {
+		// the API only partially updates the object, attributes that are no
+		// longer managed have to be cleared explicitly
+		cleared, diags = r.clearedAttributeIds(ctx, workspaceId, state, plan, priorWriteOnly, writeOnlyAttributeIds(writeOnly))
+	} else {
+		// the object moves to another object type, whose attributes replace
+		// those of the prior object type
+		attributes, diags = r.moveAttributes(ctx, workspaceId, plan, attributes)
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create payload
+	payload := newObjectUpdatePayload(plan, attributes, cleared)
+
+	// update object
+	tflog.Info(ctx, "Updating object.", map[string]interface{}{
+		"Id":      plan.Id.ValueString(),
+		"cleared": cleared,
+	})
+
+	object, response, err := updateObject(ctx, r.client, workspaceId, plan.Id.ValueString(), payload)
+	if err != nil {
+		r.logAPIError(ctx, "Error updating object.", response)
+
+		resp.Diagnostics.AddError(
+			"Error during object update",
+			err.Error(),
+		)
+		return
+	}
+
+	// Update resource state with updated object and attributes
+	plan.WorkspaceId = types.StringValue(object.WorkspaceId)
+	plan.GlobalId = types.StringValue(object.GlobalId)
+	plan.Id = types.StringValue(object.ID)
+	plan.Label = types.StringValue(object.Label)
+	plan.ObjectKey = types.StringValue(object.ObjectKey)
+	plan.Created = types.StringValue(object.Created)
+	plan.Updated = types.StringValue(object.Updated)
+	plan.HasAvatar = types.BoolValue(object.HasAvatar)
+	var attrs []*objectAttribute
+	plan.AllAttributes, attrs = r.readAllAttributes(ctx, workspaceId, plan, writeOnlyAttributeIds(writeOnly), &resp.Diagnostics)
+
+	resp.Diagnostics.Append(setWriteOnlyAttributeIds(ctx, resp.Private, writeOnlyAttributeIds(writeOnly))...)
+	resp.Diagnostics.Append(setReferenceIds(ctx, resp.Private, aqlReferenceIds(plan.References, attrs))...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// setReferences sets the references of a created object, waiting for the
+// referenced objects to exist. The object is created by then, so a failure
+// leaves it tainted.
+func (r *objectResource) setReferences(ctx context.Context, workspaceId string, plan *objectResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	references, d := r.referenceAttributes(ctx, workspaceId, *plan, true)
+	diags.Append(d...)
+	if diags.HasError() {
+		diags.AddError(
+			"Object Created Without References",
+			fmt.Sprintf("Object %s was created, but its references could not be set. Terraform marks it as tainted and replaces it on the next apply, "+
+				"run terraform untaint to set its references instead.", plan.ObjectKey.ValueString()),
+		)
+		return diags
+	}
+
+	tflog.Info(ctx, "Setting object references.", map[string]interface{}{
+		"Id": plan.Id.ValueString(),
+	})
+
+	object, response, err := updateObject(ctx, r.client, workspaceId, plan.Id.ValueString(), newObjectUpdatePayload(*plan, references, nil))
+	if err != nil {
+		diags.AddError(
+			"Object Created Without References",
+			fmt.Sprintf("Object %s was created, but its references could not be set: %s", plan.ObjectKey.ValueString(), wrapAPIError(response, err)),
+		)
+		return diags
+	}
+
+	plan.Label = types.StringValue(object.Label)
+	plan.Updated = types.StringValue(object.Updated)
+
+	return diags
+}
+
+// readAllAttributes returns the values of every attribute of the object after
+// it was written, except the sensitive and write-only ones, and the attributes
+// they were read from. Failing to read them does not fail the apply, the
+// values are filled in by the next refresh.
+func (r *objectResource) readAllAttributes(ctx context.Context, workspaceId string, m objectResourceModel, writeOnly map[string]bool, diagnostics *diag.Diagnostics) (types.Map, []*objectAttribute) {
+	empty := types.MapValueMust(types.ListType{ElemType: types.StringType}, map[string]attr.Value{})
+
+	definitions, err := r.metadata.ObjectTypeAttributes(ctx, workspaceId, m.TypeId.ValueString())
+	if err != nil {
+		diagnostics.AddWarning("Unable to Read Object Type Attributes", err.Error())
+		return empty, nil
+	}
+
+	attrs, response, err := getObjectAttributes(ctx, r.client, workspaceId, m.Id.ValueString())
+	if err != nil {
+		diagnostics.AddWarning("Unable to Read Object Attributes", wrapAPIError(response, err).Error())
+		return empty, nil
+	}
+
+	all, diags := allAttributesValue(ctx, definitions, attrs, m.hiddenAttributeIds(writeOnly))
+	diagnostics.Append(diags...)
+
+	return all, attrs
+}
+
+// moveAttributes returns the attributes of an object moved to another object
+// type: the configured attributes and the values attribute_mapping carries
+// over from the prior object type.
+func (r *objectResource) moveAttributes(ctx context.Context, workspaceId string, plan objectResourceModel, attributes []*models.ObjectPayloadAttributeScheme) ([]*models.ObjectPayloadAttributeScheme, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	attrs, response, err := getObjectAttributes(ctx, r.client, workspaceId, plan.Id.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error during object attributes reading",
+			wrapAPIError(response, err).Error(),
+		)
+		return nil, diags
+	}
+
+	configured := map[string]bool{}
+	for _, attribute := range attributes {
+		configured[attribute.ObjectTypeAttributeID] = true
+	}
+
+	mapped, diags := mappedAttributes(ctx, plan, attrs, configured)
+
+	tflog.Info(ctx, "Moving object to another object type.", map[string]interface{}{
+		"Id":             plan.Id.ValueString(),
+		"object_type_id": plan.TypeId.ValueString(),
+		"mapped":         len(mapped),
+	})
+
+	return append(attributes, mapped...), diags
+}
+
+// newObjectUpdatePayload returns the payload that sets the attributes of the
+// planned object and clears the attributes with the given IDs.
+func newObjectUpdatePayload(plan objectResourceModel, attributes []*models.ObjectPayloadAttributeScheme, cleared []string) *objectUpdatePayload {
+	payload := &objectUpdatePayload{
+		ObjectTypeID: plan.TypeId.ValueString(),
+		HasAvatar:    plan.HasAvatar.ValueBool(),
+		AvatarUUID:   plan.AvatarUuid.ValueString(),
+	}
+
+	for _, attribute := range attributes {
+		payload.Attributes = append(payload.Attributes, &objectUpdateAttribute{
+			ObjectTypeAttributeID: attribute.ObjectTypeAttributeID,
+			ObjectAttributeValues: attribute.ObjectAttributeValues,
+		})
+	}
+
+	for _, id := range cleared {
+		payload.Attributes = append(payload.Attributes, &objectUpdateAttribute{
+			ObjectTypeAttributeID: id,
+			ObjectAttributeValues: []*models.ObjectPayloadAttributeValueScheme{},
+		})
+	}
+
+	return payload
+}
+
+// clearedAttributeIds returns the IDs of the attributes the update clears:
+// those removed from the configuration and, with authoritative attribute
+// management, every other clearable attribute the object has values for.
+// The IDs of the write-only attributes are passed separately, as their
+// values are neither in the state nor in the plan.
+func (r *objectResource) clearedAttributeIds(ctx context.Context, workspaceId string, state, plan objectResourceModel, priorWriteOnly, plannedWriteOnly map[string]bool) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	prior, d := state.managedAttributeIds(ctx)
+	diags.Append(d...)
+	planned, d := plan.managedAttributeIds(ctx)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for id := range priorWriteOnly {
+		prior[id] = true
+	}
+	for id := range plannedWriteOnly {
+		planned[id] = true
+	}
+
+	cleared := removedAttributeIds(prior, planned)
+	if !plan.isAuthoritative() {
+		return cleared, diags
+	}
+
+	for _, id := range cleared {
+		planned[id] = true
+	}
+
+	unmanaged, d := r.unmanagedObjectAttributeIds(ctx, workspaceId, plan.TypeId.ValueString(), plan.Id.ValueString(), planned)
+	diags.Append(d...)
+
+	return append(cleared, unmanaged...), diags
+}
+
+// unmanagedObjectAttributeIds returns the IDs of the clearable attributes the
+// object has values for that are not planned, which authoritative attribute
+// management clears.
+func (r *objectResource) unmanagedObjectAttributeIds(ctx context.Context, workspaceId, typeId, objectId string, planned map[string]bool) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	definitions, err := r.metadata.ObjectTypeAttributes(ctx, workspaceId, typeId)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Object Type Attributes",
+			err.Error(),
+		)
+		return nil, diags
+	}
+
+	attrs, response, err := getObjectAttributes(ctx, r.client, workspaceId, objectId)
+	if err != nil {
+		diags.AddError(
+			"Error during object attributes reading",
+			wrapAPIError(response, err).Error(),
+		)
+		return nil, diags
+	}
+
+	return unmanagedAttributeIds(definitions, attrs, planned), diags
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *objectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state objectResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, "delete", deleteTimeout)
+	defer done(&resp.Diagnostics)
+
+	workspaceId := workspaceIdOrDefault(state.WorkspaceId, r.workspace_id)
+
+	switch state.DeletionPolicy.ValueString() {
+	case deletionPolicyAbandon:
+		tflog.Info(ctx, "Abandoning object, it is only removed from state.", map[string]interface{}{
+			"Id": state.Id.ValueString(),
+		})
+		return
+	case deletionPolicyArchive:
+		resp.Diagnostics.Append(archiveObject(ctx, r.client, r.metadata, workspaceId, state.Id.ValueString(), state.TypeId.ValueString(), state.Archive)...)
+		return
+	}
+
+	// Delete existing object
+	response, err := r.client.Object.Delete(ctx, workspaceId, state.Id.ValueString())
+	if isNotFound(response) {
+		// already deleted outside of Terraform
+		tflog.Info(ctx, "Object already deleted.", map[string]interface{}{
+			"Id": state.Id.ValueString(),
+		})
+		return
+	}
+	if err != nil {
+		r.logAPIError(ctx, "Error deleting object.", response)
+
+		resp.Diagnostics.AddError(
+			"Error during object deletion",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports an object by its ID, object key or global ID. IDs and
+// object keys may be qualified with the workspace the object belongs to as
+// <workspace_id>/<object_id> or <workspace_id>/<object_key>.
+func (r *objectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// the timeouts block is not configured yet when importing
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	defer cancel()
+
+	workspaceId, objectId, objectKey, err := parseObjectImportId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			err.Error(),
+		)
+		return
+	}
+
+	if objectKey != "" {
+		objectId, err = r.metadata.ObjectIdByKey(ctx, workspaceIdOrDefault(types.StringValue(workspaceId), r.workspace_id), objectKey)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Resolve Object Key",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	if workspaceId != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_id"), workspaceId)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), objectId)...)
+}
+
+// parseObjectImportId splits an import identifier into the workspace ID, if
+// given, and either the object ID or the object key to resolve.
+func parseObjectImportId(id string) (workspaceId, objectId, objectKey string, err error) {
+	invalid := fmt.Errorf("Expected import identifier with format: <object_id>, <object_key>, <global_id>, "+
+		"<workspace_id>/<object_id> or <workspace_id>/<object_key>. Got: %q", id)
+
+	identifier := id
+	if prefix, rest, found := strings.Cut(id, "/"); found {
+		if prefix == "" || rest == "" {
+			return "", "", "", invalid
+		}
+		workspaceId, identifier = prefix, rest
+	}
+
+	switch {
+	case isNumeric(identifier):
+		return workspaceId, identifier, "", nil
+	case strings.Contains(identifier, ":"):
+		// global IDs are <workspace_id>:<object_id>
+		index := strings.LastIndex(identifier, ":")
+		if workspaceId != "" || index == 0 || !isNumeric(identifier[index+1:]) {
+			return "", "", "", invalid
+		}
+		return identifier[:index], identifier[index+1:], "", nil
+	case identifier != "":
+		return workspaceId, "", identifier, nil
+	default:
+		return "", "", "", invalid
+	}
+}
+
+// isNumeric reports whether s is a non-empty string of digits.
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// logAPIError logs a failed API response. Headers and body are redacted like
+// the TRACE log of the HTTP transport, as the body may echo sensitive values.
+func (r *objectResource) logAPIError(ctx context.Context, message string, response *models.ResponseScheme) {
+	if response == nil {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"status_code": response.StatusCode,
+	}
+	if response.Request != nil {
+		fields["url"] = response.Request.URL.String()
+	}
+	if r.redactor != nil {
+		fields["headers"] = r.redactor.Headers(response.Header)
+		fields["body"] = r.redactor.Redact(response.Bytes.String())
+	}
+
+	tflog.Error(ctx, message, fields)
 }
 
 // Configure configures the resource with the given configuration.
@@ -416,4 +1646,6 @@ func (r *objectResource) Configure(ctx context.Context, req resource.ConfigureRe
 
 	r.client = providerClient.client
 	r.workspace_id = providerClient.workspaceId
+	r.metadata = providerClient.metadata
+	r.redactor = providerClient.redactor
 }
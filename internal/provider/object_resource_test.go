@@ -1,9 +1,18 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/ctreminiom/go-atlassian/assets"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccJiraAssetsObjectResource(t *testing.T) {
@@ -28,6 +37,28 @@ func TestAccJiraAssetsObjectResource(t *testing.T) {
 					resource.TestCheckResourceAttr("jiraassets_object.test", "attributes.#", "2"),
 				),
 			},
+			{
+				ResourceName:      "jiraassets_object.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"all_attributes",
+					"updated",
+				},
+			},
+			{
+				ResourceName: "jiraassets_object.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["jiraassets_object.test"].Primary.Attributes["object_key"], nil
+				},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["type_id"] != "117" {
+						return fmt.Errorf("expected an object of type 117, got %v", states)
+					}
+					return nil
+				},
+			},
 			{
 				Config: `resource "jiraassets_object" "test_avatar" {
 					type_id = "117"
@@ -50,3 +81,326 @@ func TestAccJiraAssetsObjectResource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccJiraAssetsObjectResource_attributeNames(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "jiraassets_object" "test_names" {
+					type_id = "117"
+					attribute_values = {
+						"Name" = "My Phone"
+					}
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jiraassets_object.test_names", "attribute_values.Name", "My Phone"),
+					resource.TestCheckResourceAttr("jiraassets_object.test_names", "all_attributes.Name.0", "My Phone"),
+					resource.TestCheckResourceAttrSet("jiraassets_object.test_names", "attribute_ids.Name"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccJiraAssetsObjectResource_removedAttribute(t *testing.T) {
+	bothAttributes := `resource "jiraassets_object" "test_removed" {
+		type_id = "117"
+		attributes = [
+			{
+				attr_type_id = "1087"
+				attr_value = "My Phone"
+			},
+			{
+				attr_type_id = "1090"
+				attr_value = "1234657890"
+			}
+		]
+	}`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: bothAttributes,
+			},
+			{
+				// partial management clears attributes removed from the configuration
+				Config: `resource "jiraassets_object" "test_removed" {
+					type_id = "117"
+					attribute_management = "partial"
+					attributes = [
+						{
+							attr_type_id = "1087"
+							attr_value = "My Phone"
+						}
+					]
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jiraassets_object.test_removed", "attributes.#", "1"),
+					testAccCheckAttributeCleared("jiraassets_object.test_removed", "1090"),
+				),
+			},
+			{
+				Config: bothAttributes,
+			},
+			{
+				Config: `resource "jiraassets_object" "test_removed" {
+					type_id = "117"
+					attribute_management = "authoritative"
+					attributes = [
+						{
+							attr_type_id = "1087"
+							attr_value = "My Phone"
+						}
+					]
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jiraassets_object.test_removed", "attributes.#", "1"),
+					testAccCheckAttributeCleared("jiraassets_object.test_removed", "1090"),
+				),
+			},
+			{
+				// a cleared attribute is not refreshed back into state, so the
+				// plan is empty
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jiraassets_object.test_removed", "attributes.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckAttributeCleared checks that the object of the resource has no
+// values for the attribute in Assets.
+func testAccCheckAttributeCleared(name, attrTypeId string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+
+		client, err := testAccAssetsClient()
+		if err != nil {
+			return err
+		}
+
+		attrs, response, err := getObjectAttributes(context.Background(), client, rs.Primary.Attributes["workspace_id"], rs.Primary.ID)
+		if err != nil {
+			return wrapAPIError(response, err)
+		}
+
+		if values := objectAttributeEntries(attrs, attrTypeId); len(values) > 0 {
+			return fmt.Errorf("attribute %s of object %s still has value %q", attrTypeId, rs.Primary.ID, values[0].rawValue())
+		}
+
+		return nil
+	}
+}
+
+func TestAccJiraAssetsObjectResource_disappears(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "jiraassets_object" "test_disappears" {
+					type_id = "117"
+					attributes = [
+						{
+							attr_type_id = "1087"
+							attr_value = "My Phone"
+						}
+					]
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccDeleteObject("jiraassets_object.test_disappears"),
+				),
+				// the refresh after apply removes the deleted object from state
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// testAccDeleteObject deletes the object of the resource outside of Terraform.
+func testAccDeleteObject(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+
+		client, err := testAccAssetsClient()
+		if err != nil {
+			return err
+		}
+
+		response, err := client.Object.Delete(context.Background(), rs.Primary.Attributes["workspace_id"], rs.Primary.ID)
+
+		return wrapAPIError(response, err)
+	}
+}
+
+// testAccAssetsClient returns an Assets client authenticated like the provider
+// in acceptance tests, to check or change objects outside of Terraform.
+func testAccAssetsClient() (*assets.Client, error) {
+	client, err := assets.New(http.DefaultClient, "")
+	if err != nil {
+		return nil, err
+	}
+	client.Auth.SetBasicAuth(os.Getenv("JIRAASSETS_USER"), os.Getenv("JIRAASSETS_PASSWORD"))
+
+	return client, nil
+}
+
+func TestAccJiraAssetsObjectResource_invalidAttribute(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "jiraassets_object" "test_invalid" {
+					type_id = "117"
+					attributes = [
+						{
+							attr_type_id = "1087"
+							attr_value = "My Phone"
+						},
+						{
+							attr_type_id = "999999"
+							attr_value = "Unknown"
+						}
+					]
+				}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Unknown Attribute Type"),
+			},
+		},
+	})
+}
+
+func TestAccJiraAssetsObjectResource_archiveWithoutConfiguration(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "jiraassets_object" "test_archive" {
+					type_id = "117"
+					attributes = [
+						{
+							attr_type_id = "1087"
+							attr_value = "My Phone"
+						}
+					]
+					deletion_policy = "archive"
+				}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Missing Archive Configuration"),
+			},
+		},
+	})
+}
+
+func TestAccJiraAssetsObjectResource_writeOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "jiraassets_object" "test_write_only" {
+					type_id = "117"
+					attributes = [
+						{
+							attr_type_id = "1087"
+							attr_value = "My Phone"
+						}
+					]
+					attr_values_wo = {
+						"1090" = ["1234657890"]
+					}
+					attr_values_wo_version = 1
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("jiraassets_object.test_write_only", "attr_values_wo"),
+					resource.TestCheckResourceAttr("jiraassets_object.test_write_only", "attr_values_wo_version", "1"),
+					resource.TestCheckNoResourceAttr("jiraassets_object.test_write_only", "all_attributes.Serial Number.#"),
+				),
+			},
+			{
+				// changed write-only values are only sent with a new version
+				Config: `resource "jiraassets_object" "test_write_only" {
+					type_id = "117"
+					attributes = [
+						{
+							attr_type_id = "1087"
+							attr_value = "My Phone"
+						}
+					]
+					attr_values_wo = {
+						"1090" = ["0987654321"]
+					}
+					attr_values_wo_version = 2
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jiraassets_object.test_write_only", "attr_values_wo_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseObjectImportId(t *testing.T) {
+	testCases := map[string]struct {
+		workspaceId, objectId, objectKey string
+		invalid                          bool
+	}{
+		"123":       {"", "123", "", false},
+		"HW-42":     {"", "", "HW-42", false},
+		"ws:123":    {"ws", "123", "", false},
+		"ws/123":    {"ws", "123", "", false},
+		"ws/HW-42":  {"ws", "", "HW-42", false},
+		"ws/":       {"", "", "", true},
+		"/123":      {"", "", "", true},
+		":123":      {"", "", "", true},
+		"ws:HW-42":  {"", "", "", true},
+		"ws/ws:123": {"", "", "", true},
+		"":          {"", "", "", true},
+	}
+
+	for id, testCase := range testCases {
+		t.Run(id, func(t *testing.T) {
+			workspaceId, objectId, objectKey, err := parseObjectImportId(id)
+			if (err != nil) != testCase.invalid {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if workspaceId != testCase.workspaceId || objectId != testCase.objectId || objectKey != testCase.objectKey {
+				t.Errorf("expected %q, %q, %q, got %q, %q, %q", testCase.workspaceId, testCase.objectId, testCase.objectKey, workspaceId, objectId, objectKey)
+			}
+		})
+	}
+}
+
+func TestKeepValueOrder(t *testing.T) {
+	testCases := map[string]struct {
+		prior, refreshed, expected []string
+	}{
+		"same order":      {[]string{"a", "b"}, []string{"a", "b"}, []string{"a", "b"}},
+		"reordered":       {[]string{"b", "a"}, []string{"a", "b"}, []string{"b", "a"}},
+		"duplicates":      {[]string{"a", "a", "b"}, []string{"a", "b", "b"}, []string{"a", "b", "b"}},
+		"value added":     {[]string{"b", "a"}, []string{"a", "b", "c"}, []string{"a", "b", "c"}},
+		"value replaced":  {[]string{"b", "a"}, []string{"a", "c"}, []string{"a", "c"}},
+		"values removed":  {[]string{"a"}, nil, nil},
+		"no prior values": {nil, []string{"a"}, []string{"a"}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := keepValueOrder(testCase.prior, testCase.refreshed)
+			if strings.Join(got, ",") != strings.Join(testCase.expected, ",") {
+				t.Errorf("expected %v, got %v", testCase.expected, got)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ func NewObjectSchemaDataSource() datasource.DataSource {
 type objectSchemaDataSource struct {
 	client       *assets.Client
 	workspace_id string
+	metadata     *metadataCache
 }
 
 // Metadata returns the data source type name.
@@ -58,7 +59,9 @@ func (d *objectSchemaDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 				Description: "The ID of the object schema.",
 			},
 			"workspace_id": schema.StringAttribute{
-				Computed: true,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the workspace the object schema belongs to. Defaults to the provider workspace_id.",
 			},
 			"global_id": schema.StringAttribute{
 				Computed: true,
@@ -109,8 +112,14 @@ func (d *objectSchemaDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	// Call the API to get the object schema
-	schema, schemaResp, err := d.client.ObjectSchema.Get(ctx, d.workspace_id, state.Id.ValueString())
+	workspaceId := workspaceIdOrDefault(state.WorkspaceId, d.workspace_id)
+
+	// data sources have no timeouts block, reads are expected to be quick
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	defer cancel()
+
+	// Get the object schema through the metadata cache, the API returns an error for any non 2xx status
+	schema, err := d.metadata.ObjectSchema(ctx, workspaceId, state.Id.ValueString())
 
 	// Return an error if the API call fails
 	if err != nil {
@@ -121,15 +130,6 @@ func (d *objectSchemaDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	// Return an error if the HTTP status code is not 200
-	if schemaResp.StatusCode != 200 {
-		resp.Diagnostics.AddError(
-			"Unexpected HTTP status code from Assets API",
-			schemaResp.Status,
-		)
-		return
-	}
-
 	state = objectSchemaDataSourceModel{
 		WorkspaceId:     types.StringValue(schema.WorkspaceId),
 		GlobalId:        types.StringValue(schema.GlobalId),
@@ -169,4 +169,5 @@ func (d *objectSchemaDataSource) Configure(ctx context.Context, req datasource.C
 
 	d.client = providerClient.client
 	d.workspace_id = providerClient.workspaceId
+	d.metadata = providerClient.metadata
 }
@@ -0,0 +1,643 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &objectScopeResource{}
+	_ resource.ResourceWithConfigure = &objectScopeResource{}
+
+	_ resource.ResourceWithValidateConfig = &objectScopeResource{}
+	_ resource.ResourceWithModifyPlan     = &objectScopeResource{}
+)
+
+// defaultMaxPrune is the number of objects a scope prunes at most when
+// max_prune is not set, and scopePageSize the number of objects listed per request.
+const (
+	defaultMaxPrune = 10
+	scopePageSize   = 100
+)
+
+// NewObjectScopeResource is a helper function to simplify the provider implementation.
+func NewObjectScopeResource() resource.Resource {
+	return &objectScopeResource{}
+}
+
+// objectScopeResource makes Terraform authoritative for the objects matching
+// an AQL query, pruning the objects it does not manage.
+type objectScopeResource struct {
+	client       *assets.Client
+	workspace_id string
+	metadata     *metadataCache
+}
+
+// Metadata returns the resource type name.
+func (r *objectScopeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_scope"
+}
+
+type objectScopeResourceModel struct {
+	Id               types.String        `tfsdk:"id"`
+	WorkspaceId      types.String        `tfsdk:"workspace_id"`
+	Aql              types.String        `tfsdk:"aql"`
+	ManagedIds       types.Set           `tfsdk:"managed_ids"`
+	ManagedKeys      types.Set           `tfsdk:"managed_keys"`
+	PruneAction      types.String        `tfsdk:"prune_action"`
+	Archive          *objectArchiveModel `tfsdk:"archive"`
+	MaxPrune         types.Int64         `tfsdk:"max_prune"`
+	UnmanagedObjects types.Map           `tfsdk:"unmanaged_objects"`
+	Timeouts         timeouts.Value      `tfsdk:"timeouts"`
+}
+
+// Schema defines the schema for the resource.
+func (r *objectScopeResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Makes Terraform the source of truth for the objects matching an AQL query. " +
+			"Objects in scope that are neither in managed_ids nor in managed_keys are listed on refresh " +
+			"and pruned by the next apply. Destroying the scope keeps all objects.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the scope, the workspace ID and the AQL query separated by a slash.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workspace_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the workspace of the objects. Defaults to the provider workspace_id.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"aql": schema.StringAttribute{
+				Required:    true,
+				Description: "The AQL query selecting the objects in scope, such as objectType = \"Cloud Accounts\".",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"managed_ids": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of the objects in scope that are managed, such as the id of jiraassets_object resources.",
+			},
+			"managed_keys": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The object keys of the objects in scope that are managed.",
+			},
+			"prune_action": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(deletionPolicyDelete),
+				Description: "What happens to unmanaged objects: \"delete\" deletes them, \"archive\" archives them as configured by archive. Defaults to \"delete\".",
+			},
+			"archive": archiveSchemaAttribute("How unmanaged objects are archived. Required when prune_action is \"archive\"."),
+			"max_prune": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(defaultMaxPrune),
+				Description: fmt.Sprintf("The most objects a single apply may prune. Plans that would prune more fail, "+
+					"which protects against a wrong query or a missing managed object. Defaults to %d.", defaultMaxPrune),
+			},
+			"unmanaged_objects": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The object IDs of the objects in scope that are not managed by object key, as of the last refresh.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// ValidateConfig checks prune_action and max_prune.
+func (r *objectScopeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var pruneAction types.String
+	var archive types.Object
+	var maxPrune types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("prune_action"), &pruneAction)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("archive"), &archive)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("max_prune"), &maxPrune)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch pruneAction.ValueString() {
+	case "", deletionPolicyDelete:
+	case deletionPolicyArchive:
+		if archive.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("archive"),
+				"Missing Archive Configuration",
+				"archive must be set when prune_action is \"archive\".",
+			)
+		}
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("prune_action"),
+			"Invalid Prune Action",
+			fmt.Sprintf("prune_action must be %q or %q. Got: %q", deletionPolicyDelete, deletionPolicyArchive, pruneAction.ValueString()),
+		)
+	}
+
+	if !maxPrune.IsNull() && !maxPrune.IsUnknown() && maxPrune.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_prune"),
+			"Invalid Max Prune",
+			fmt.Sprintf("max_prune must not be negative. Got: %d", maxPrune.ValueInt64()),
+		)
+	}
+}
+
+// ModifyPlan plans the pruning of the unmanaged objects. The objects found by
+// the last refresh are pruned, unless they are managed by the planned
+// configuration. A new scope lists the objects in scope instead.
+func (r *objectScopeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// destroying the scope keeps the objects
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	ctx, done, diags := withPlanTimeout(ctx, req.Plan)
+	defer done(&resp.Diagnostics)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan objectScopeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Aql.IsUnknown() || plan.ManagedIds.IsUnknown() || plan.ManagedKeys.IsUnknown() || plan.MaxPrune.IsUnknown() {
+		plan.UnmanagedObjects = types.MapUnknown(types.StringType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+		return
+	}
+
+	var state *objectScopeResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &objectScopeResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	pruned, diags := r.plannedPrunes(ctx, state, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if int64(len(pruned)) > plan.MaxPrune.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_prune"),
+			"Too Many Objects to Prune",
+			fmt.Sprintf("%d objects in scope are not managed, more than max_prune (%d): %s. "+
+				"Add the objects to managed_ids or managed_keys, narrow aql, or raise max_prune if they should all be pruned.",
+				len(pruned), plan.MaxPrune.ValueInt64(), summarizeKeys(pruned)),
+		)
+		return
+	}
+
+	if len(pruned) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Unmanaged Objects Are Pruned",
+			fmt.Sprintf("The apply %ss %d objects in scope that are not managed: %s.", plan.PruneAction.ValueString(), len(pruned), summarizeKeys(pruned)),
+		)
+	}
+
+	// no unmanaged object remains once they are pruned
+	plan.UnmanagedObjects = types.MapValueMust(types.StringType, map[string]attr.Value{})
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// plannedPrunes returns the keys of the unmanaged objects the plan prunes,
+// sorted. The objects found by the last refresh are pruned unless the plan
+// manages them, a new scope without a prior state lists the objects in scope.
+// The managed objects must be known.
+func (r *objectScopeResource) plannedPrunes(ctx context.Context, state *objectScopeResourceModel, plan objectScopeResourceModel) ([]string, diag.Diagnostics) {
+	ids, keys, diags := plan.managed(ctx)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var pruned []string
+	if state == nil {
+		workspaceId := workspaceIdOrDefault(plan.WorkspaceId, r.workspace_id)
+
+		objects, err := listScopeObjects(ctx, r.client, workspaceId, plan.Aql.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("aql"),
+				"Unable to List Objects in Scope",
+				err.Error(),
+			)
+			return nil, diags
+		}
+
+		for key := range unmanagedObjects(objects, ids, keys) {
+			pruned = append(pruned, key)
+		}
+	} else {
+		var unmanaged map[string]string
+		if !state.UnmanagedObjects.IsNull() && !state.UnmanagedObjects.IsUnknown() {
+			diags.Append(state.UnmanagedObjects.ElementsAs(ctx, &unmanaged, false)...)
+		}
+
+		for key, id := range unmanaged {
+			if !ids[id] && !keys[key] {
+				pruned = append(pruned, key)
+			}
+		}
+	}
+	sort.Strings(pruned)
+
+	return pruned, diags
+}
+
+// plannedDeletes returns the unmanaged objects the plan prunes when
+// prune_action deletes them. With managed objects unknown at plan time, they
+// are checked when the plan is applied.
+func (r *objectScopeResource) plannedDeletes(ctx context.Context, state tfsdk.State, plan tfsdk.Plan) ([]string, diag.Diagnostics) {
+	var planned objectScopeResourceModel
+	diags := plan.Get(ctx, &planned)
+	if diags.HasError() || planned.PruneAction.ValueString() != deletionPolicyDelete {
+		return nil, diags
+	}
+
+	if planned.Aql.IsUnknown() || planned.ManagedIds.IsUnknown() || planned.ManagedKeys.IsUnknown() {
+		return nil, diags
+	}
+
+	var prior *objectScopeResourceModel
+	if !state.Raw.IsNull() {
+		prior = &objectScopeResourceModel{}
+		diags.Append(state.Get(ctx, prior)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	pruned, d := r.plannedPrunes(ctx, prior, planned)
+	diags.Append(d...)
+
+	return pruned, diags
+}
+
+// managed returns the managed object IDs and keys.
+func (m objectScopeResourceModel) managed(ctx context.Context) (map[string]bool, map[string]bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var idList, keyList []string
+	if !m.ManagedIds.IsNull() {
+		diags.Append(m.ManagedIds.ElementsAs(ctx, &idList, false)...)
+	}
+	if !m.ManagedKeys.IsNull() {
+		diags.Append(m.ManagedKeys.ElementsAs(ctx, &keyList, false)...)
+	}
+
+	ids := map[string]bool{}
+	for _, id := range idList {
+		ids[id] = true
+	}
+
+	keys := map[string]bool{}
+	for _, key := range keyList {
+		keys[key] = true
+	}
+
+	return ids, keys, diags
+}
+
+// unmanagedObjects returns the objects that are neither managed by ID nor by
+// object key, by object key.
+func unmanagedObjects(objects []*models.ObjectScheme, ids, keys map[string]bool) map[string]*models.ObjectScheme {
+	unmanaged := map[string]*models.ObjectScheme{}
+	for _, object := range objects {
+		if !ids[object.ID] && !keys[object.ObjectKey] {
+			unmanaged[object.ObjectKey] = object
+		}
+	}
+
+	return unmanaged
+}
+
+// summarizeKeys returns the object keys for a diagnostic, shortened when
+// there are many of them.
+func summarizeKeys(keys []string) string {
+	const limit = 20
+
+	if len(keys) <= limit {
+		return strings.Join(keys, ", ")
+	}
+
+	return fmt.Sprintf("%s and %d more", strings.Join(keys[:limit], ", "), len(keys)-limit)
+}
+
+// listScopeObjects returns every object matching the AQL query.
+func listScopeObjects(ctx context.Context, client *assets.Client, workspaceId, aql string) ([]*models.ObjectScheme, error) {
+	var objects []*models.ObjectScheme
+	for {
+		list, response, err := client.Object.Filter(ctx, workspaceId, aql, false, len(objects), scopePageSize)
+		if err != nil {
+			return nil, wrapAPIError(response, err)
+		}
+
+		objects = append(objects, list.Values...)
+
+		if len(list.Values) == 0 || list.IsLast || (list.Total > 0 && len(objects) >= list.Total) {
+			return objects, nil
+		}
+	}
+}
+
+// Create creates the scope, pruning the unmanaged objects in scope.
+func (r *objectScopeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan objectScopeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, "create", createTimeout)
+	defer done(&resp.Diagnostics)
+
+	workspaceId := workspaceIdOrDefault(plan.WorkspaceId, r.workspace_id)
+
+	plan.WorkspaceId = types.StringValue(workspaceId)
+	plan.Id = types.StringValue(workspaceId + "/" + plan.Aql.ValueString())
+	plan.UnmanagedObjects, diags = r.prune(ctx, workspaceId, plan, nil)
+	resp.Diagnostics.Append(diags...)
+	if plan.UnmanagedObjects.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read lists the objects in scope that are not managed.
+func (r *objectScopeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state objectScopeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, "read", readTimeout)
+	defer done(&resp.Diagnostics)
+
+	workspaceId := workspaceIdOrDefault(state.WorkspaceId, r.workspace_id)
+
+	objects, err := listScopeObjects(ctx, r.client, workspaceId, state.Aql.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Objects in Scope",
+			err.Error(),
+		)
+		return
+	}
+
+	ids, keys, diags := state.managed(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unmanaged := map[string]attr.Value{}
+	for key, object := range unmanagedObjects(objects, ids, keys) {
+		unmanaged[key] = types.StringValue(object.ID)
+	}
+
+	tflog.Debug(ctx, "Listed objects in scope.", map[string]interface{}{
+		"aql":       state.Aql.ValueString(),
+		"objects":   len(objects),
+		"unmanaged": len(unmanaged),
+	})
+
+	state.WorkspaceId = types.StringValue(workspaceId)
+	state.UnmanagedObjects, diags = types.MapValue(types.StringType, unmanaged)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update prunes the unmanaged objects found by the last refresh.
+func (r *objectScopeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state objectScopeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, "update", updateTimeout)
+	defer done(&resp.Diagnostics)
+
+	// only the objects shown in the plan are pruned, objects created since
+	// the refresh are listed by the next one
+	candidates := map[string]bool{}
+	for key := range state.UnmanagedObjects.Elements() {
+		candidates[key] = true
+	}
+
+	workspaceId := workspaceIdOrDefault(state.WorkspaceId, r.workspace_id)
+
+	plan.WorkspaceId = types.StringValue(workspaceId)
+	plan.UnmanagedObjects, diags = r.prune(ctx, workspaceId, plan, candidates)
+	resp.Diagnostics.Append(diags...)
+	if plan.UnmanagedObjects.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// destroyDeletes returns false, destroying the scope keeps the objects in scope.
+func (r *objectScopeResource) destroyDeletes(ctx context.Context, state tfsdk.State) (bool, diag.Diagnostics) {
+	return false, nil
+}
+
+// Delete removes the scope from state, the objects in scope are kept.
+func (r *objectScopeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state objectScopeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Removing object scope, the objects in scope are kept.", map[string]interface{}{
+		"aql": state.Aql.ValueString(),
+	})
+}
+
+// prune deletes or archives the unmanaged objects in scope, limited to the
+// candidates when they are given, and returns the unmanaged objects that
+// could not be pruned. It returns a null map when nothing was pruned because
+// the scope could not be listed or holds more than max_prune objects to prune.
+func (r *objectScopeResource) prune(ctx context.Context, workspaceId string, m objectScopeResourceModel, candidates map[string]bool) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	objects, err := listScopeObjects(ctx, r.client, workspaceId, m.Aql.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Unable to List Objects in Scope",
+			err.Error(),
+		)
+		return types.MapNull(types.StringType), diags
+	}
+
+	ids, keys, d := m.managed(ctx)
+	diags.Append(d...)
+	if diags.HasError() {
+		return types.MapNull(types.StringType), diags
+	}
+
+	unmanaged := unmanagedObjects(objects, ids, keys)
+	if candidates != nil {
+		for key := range unmanaged {
+			if !candidates[key] {
+				delete(unmanaged, key)
+			}
+		}
+	}
+
+	var pruned []string
+	for key := range unmanaged {
+		pruned = append(pruned, key)
+	}
+	sort.Strings(pruned)
+
+	// the scope may have changed since the plan, check the limit again
+	if int64(len(pruned)) > m.MaxPrune.ValueInt64() {
+		diags.AddAttributeError(
+			path.Root("max_prune"),
+			"Too Many Objects to Prune",
+			fmt.Sprintf("%d objects in scope are not managed, more than max_prune (%d): %s. No object was pruned.",
+				len(pruned), m.MaxPrune.ValueInt64(), summarizeKeys(pruned)),
+		)
+		return types.MapNull(types.StringType), diags
+	}
+
+	tflog.Info(ctx, "Pruning unmanaged objects.", map[string]interface{}{
+		"aql":          m.Aql.ValueString(),
+		"prune_action": m.PruneAction.ValueString(),
+		"objects":      pruned,
+	})
+
+	_, errs := parallelMap(ctx, defaultParallelism, pruned, func(ctx context.Context, key string) (struct{}, error) {
+		return struct{}{}, r.pruneObject(ctx, workspaceId, m, unmanaged[key])
+	})
+
+	remaining := map[string]attr.Value{}
+	for _, key := range pruned {
+		if err, ok := errs[key]; ok {
+			remaining[key] = types.StringValue(unmanaged[key].ID)
+			diags.AddAttributeError(
+				path.Root("unmanaged_objects").AtMapKey(key),
+				"Unable to Prune Object",
+				fmt.Sprintf("Object %s: %s", key, err),
+			)
+		}
+	}
+
+	value, d := types.MapValue(types.StringType, remaining)
+	diags.Append(d...)
+
+	return value, diags
+}
+
+// pruneObject deletes or archives an unmanaged object.
+func (r *objectScopeResource) pruneObject(ctx context.Context, workspaceId string, m objectScopeResourceModel, object *models.ObjectScheme) error {
+	if m.PruneAction.ValueString() == deletionPolicyArchive {
+		typeId := ""
+		if object.ObjectType != nil {
+			typeId = object.ObjectType.Id
+		}
+
+		diags := archiveObject(ctx, r.client, r.metadata, workspaceId, object.ID, typeId, m.Archive)
+		for _, d := range diags.Errors() {
+			return fmt.Errorf("%s: %s", d.Summary(), d.Detail())
+		}
+		return nil
+	}
+
+	response, err := r.client.Object.Delete(ctx, workspaceId, object.ID)
+	if isNotFound(response) {
+		return nil
+	}
+
+	return wrapAPIError(response, err)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *objectScopeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(JiraAssetsProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected JiraAssetsProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerClient.client
+	r.workspace_id = providerClient.workspaceId
+	r.metadata = providerClient.metadata
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUnmanagedObjects(t *testing.T) {
+	objects := []*models.ObjectScheme{
+		{ID: "1", ObjectKey: "ITSM-1"},
+		{ID: "2", ObjectKey: "ITSM-2"},
+		{ID: "3", ObjectKey: "ITSM-3"},
+		{ID: "4", ObjectKey: "ITSM-4"},
+	}
+
+	unmanaged := unmanagedObjects(objects, map[string]bool{"1": true}, map[string]bool{"ITSM-3": true})
+
+	var keys []string
+	for key := range unmanaged {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if !reflect.DeepEqual(keys, []string{"ITSM-2", "ITSM-4"}) {
+		t.Errorf("expected [ITSM-2 ITSM-4], got %v", keys)
+	}
+}
+
+func TestSummarizeKeys(t *testing.T) {
+	if got := summarizeKeys([]string{"ITSM-1", "ITSM-2"}); got != "ITSM-1, ITSM-2" {
+		t.Errorf("expected all keys, got %q", got)
+	}
+
+	keys := make([]string, 25)
+	for i := range keys {
+		keys[i] = "K"
+	}
+	if got := summarizeKeys(keys); !regexp.MustCompile(`and 5 more$`).MatchString(got) {
+		t.Errorf("expected a shortened list, got %q", got)
+	}
+}
+
+func TestObjectScopePlannedDeletes(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	(&objectScopeResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	value := func(pruneAction string, managedIds []string, unmanaged map[string]string) tftypes.Value {
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+		values := map[string]interface{}{
+			"aql":               types.StringValue(`objectType = "Laptops"`),
+			"prune_action":      types.StringValue(pruneAction),
+			"max_prune":         types.Int64Value(10),
+			"managed_ids":       managedIds,
+			"unmanaged_objects": unmanaged,
+		}
+		for name, v := range values {
+			if diags := state.SetAttribute(ctx, path.Root(name), v); diags.HasError() {
+				t.Fatal(diags)
+			}
+		}
+
+		return state.Raw
+	}
+
+	unmanaged := map[string]string{"ITSM-1": "1", "ITSM-2": "2"}
+
+	testCases := map[string]struct {
+		pruneAction string
+		expected    []string
+	}{
+		"delete":  {deletionPolicyDelete, []string{"ITSM-1"}},
+		"archive": {deletionPolicyArchive, nil},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// ITSM-2 is managed by the planned configuration, so it is kept
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: value(testCase.pruneAction, []string{}, unmanaged)}
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: value(testCase.pruneAction, []string{"2"}, map[string]string{})}
+
+			deletes, diags := (&objectScopeResource{}).plannedDeletes(ctx, state, plan)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			if !reflect.DeepEqual(deletes, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, deletes)
+			}
+		})
+	}
+}
+
+func TestAccJiraAssetsObjectScopeResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create the managed and an unmanaged object outside of the scope resource
+			{
+				Config: `resource "jiraassets_objects" "test" {
+					type_id = "117"
+					objects = {
+						"managed" = {
+							attributes = {
+								"1087" = "My Scoped Phone"
+							}
+						}
+						"unmanaged" = {
+							attributes = {
+								"1087" = "My Unmanaged Phone"
+							}
+						}
+					}
+				}`,
+			},
+			// Too many objects to prune
+			{
+				Config: `resource "jiraassets_objects" "test" {
+					type_id = "117"
+					objects = {
+						"managed" = {
+							attributes = {
+								"1087" = "My Scoped Phone"
+							}
+						}
+						"unmanaged" = {
+							attributes = {
+								"1087" = "My Unmanaged Phone"
+							}
+						}
+					}
+				}
+
+				resource "jiraassets_object_scope" "test" {
+					aql       = "objectTypeId = 117 AND Name STARTSWITH \"My Scoped\""
+					max_prune = 0
+				}`,
+				ExpectError: regexp.MustCompile("Too Many Objects to Prune"),
+			},
+			// Only the managed object is in scope, nothing is pruned
+			{
+				Config: `resource "jiraassets_objects" "test" {
+					type_id = "117"
+					objects = {
+						"managed" = {
+							attributes = {
+								"1087" = "My Scoped Phone"
+							}
+						}
+						"unmanaged" = {
+							attributes = {
+								"1087" = "My Unmanaged Phone"
+							}
+						}
+					}
+				}
+
+				resource "jiraassets_object_scope" "test" {
+					aql         = "objectTypeId = 117 AND Name STARTSWITH \"My Scoped\""
+					managed_ids = [jiraassets_objects.test.objects["managed"].id]
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jiraassets_object_scope.test", "prune_action", "delete"),
+					resource.TestCheckResourceAttr("jiraassets_object_scope.test", "max_prune", "10"),
+					resource.TestCheckResourceAttr("jiraassets_object_scope.test", "unmanaged_objects.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccJiraAssetsObjectScopeResource_invalidPruneAction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "jiraassets_object_scope" "test" {
+					aql          = "objectTypeId = 117"
+					prune_action = "archive"
+				}`,
+				ExpectError: regexp.MustCompile("Missing Archive Configuration"),
+			},
+		},
+	})
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// minMaskedValueLength is the length below which values are not masked. Short
+// values such as "1", "true" or "prod" are not secrets, and replacing every
+// occurrence of them would make logs unreadable.
+const minMaskedValueLength = 6
+
+// objectSensitiveAttrModel is an attribute whose value is a secret. Terraform
+// shows it as sensitive in plans and the provider masks it in its logs.
+type objectSensitiveAttrModel struct {
+	AttrTypeId types.String `tfsdk:"attr_type_id"`
+	AttrValue  types.String `tfsdk:"attr_value"`
+}
+
+// attribute returns the sensitive attribute as an attribute of the attributes set.
+func (m objectSensitiveAttrModel) attribute() objectAttrResourceModel {
+	attr := objectAttrResourceModel{
+		AttrTypeId: m.AttrTypeId,
+	}
+	attr.setNullValues()
+	attr.AttrValue = m.AttrValue
+
+	return attr
+}
+
+// sensitiveAttributeIds returns the IDs of the sensitive attributes.
+func (m objectResourceModel) sensitiveAttributeIds() map[string]bool {
+	ids := map[string]bool{}
+	for _, attr := range m.SensitiveAttributes {
+		ids[attr.AttrTypeId.ValueString()] = true
+	}
+
+	return ids
+}
+
+// maskSensitiveValues masks the sensitive attribute values of the objects in
+// every log written with the returned context, and in the HTTP logs of the
+// provider.
+func (r *objectResource) maskSensitiveValues(ctx context.Context, objects ...objectResourceModel) context.Context {
+	var values []string
+	for _, object := range objects {
+		for _, attr := range object.SensitiveAttributes {
+			if !attr.AttrValue.IsNull() && !attr.AttrValue.IsUnknown() {
+				values = append(values, attr.AttrValue.ValueString())
+			}
+		}
+	}
+
+	return r.maskValues(ctx, values)
+}
+
+// maskWriteOnlyValues masks the values of attr_values_wo like the values of
+// sensitive attributes.
+func (r *objectResource) maskWriteOnlyValues(ctx context.Context, writeOnly map[string][]string) context.Context {
+	var values []string
+	for _, list := range writeOnly {
+		values = append(values, list...)
+	}
+
+	return r.maskValues(ctx, values)
+}
+
+// maskValues masks the values in every log written with the returned context
+// and registers them with the redactor of the HTTP logs. Values are masked as
+// configured and as encoded in JSON bodies, except values shorter than
+// minMaskedValueLength.
+func (r *objectResource) maskValues(ctx context.Context, values []string) context.Context {
+	var masked []string
+	for _, value := range values {
+		if len(value) < minMaskedValueLength {
+			continue
+		}
+
+		masked = append(masked, value)
+
+		if encoded, err := json.Marshal(value); err == nil {
+			masked = append(masked, strings.Trim(string(encoded), `"`))
+		}
+	}
+
+	if len(masked) == 0 {
+		return ctx
+	}
+
+	if r.redactor != nil {
+		r.redactor.Add(masked...)
+	}
+
+	ctx = tflog.MaskAllFieldValuesStrings(ctx, masked...)
+	ctx = tflog.MaskMessageStrings(ctx, masked...)
+
+	return ctx
+}
@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMaskSensitiveValues(t *testing.T) {
+	r := &objectResource{redactor: newRedactor()}
+
+	object := objectResourceModel{
+		SensitiveAttributes: []objectSensitiveAttrModel{
+			{AttrTypeId: types.StringValue("1"), AttrValue: types.StringValue(`pass"word`)},
+			{AttrTypeId: types.StringValue("2"), AttrValue: types.StringNull()},
+			{AttrTypeId: types.StringValue("3"), AttrValue: types.StringValue("true")},
+		},
+	}
+
+	r.maskSensitiveValues(context.Background(), object)
+
+	// short values are not masked
+	body := `{"objectAttributeValues":[{"value":"pass\"word"},{"value":"true"}]} pass"word`
+	if got, want := r.redactor.Redact(body), `{"objectAttributeValues":[{"value":"`+redactedValue+`"},{"value":"true"}]} `+redactedValue; got != want {
+		t.Errorf("Redact() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Values of type_change.
+const (
+	// typeChangeReplace replaces the object when its object type changes.
+	typeChangeReplace = "replace"
+
+	// typeChangeMove moves the object to the new object type in place, when
+	// both object types belong to the same object schema.
+	typeChangeMove = "move"
+)
+
+// planTypeChange plans the change of the object type of an existing object.
+// The object is replaced, unless type_change is "move" and both object types
+// belong to the same schema, in which case the plan warns about the attributes
+// whose values are lost because attribute_mapping does not carry them over.
+func (r *objectResource) planTypeChange(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, workspaceId, typeId string) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state objectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || state.TypeId.IsNull() || state.TypeId.ValueString() == typeId {
+		return
+	}
+
+	var typeChange types.String
+	var attributeMapping types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type_change"), &typeChange)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("attribute_mapping"), &attributeMapping)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if typeChange.ValueString() != typeChangeMove || attributeMapping.IsUnknown() {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("type_id"))
+		return
+	}
+
+	from, err := r.metadata.ObjectType(ctx, workspaceId, state.TypeId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Object Type", err.Error())
+		return
+	}
+
+	to, err := r.metadata.ObjectType(ctx, workspaceId, typeId)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Object Type", err.Error())
+		return
+	}
+
+	if from.ObjectSchemaId != to.ObjectSchemaId {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("type_id"),
+			"Object Type Change Requires Replacement",
+			fmt.Sprintf("Objects can only be moved between object types of the same object schema. "+
+				"Object type %s belongs to object schema %s and object type %s to object schema %s, so the object is replaced.",
+				from.Id, from.ObjectSchemaId, to.Id, to.ObjectSchemaId),
+		)
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("type_id"))
+		return
+	}
+
+	definitions, err := r.metadata.ObjectTypeAttributes(ctx, workspaceId, state.TypeId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Object Type Attributes", err.Error())
+		return
+	}
+
+	var mapping map[string]string
+	if !attributeMapping.IsNull() {
+		resp.Diagnostics.Append(attributeMapping.ElementsAs(ctx, &mapping, false)...)
+	}
+
+	var all map[string][]string
+	if !state.AllAttributes.IsNull() {
+		resp.Diagnostics.Append(state.AllAttributes.ElementsAs(ctx, &all, false)...)
+	}
+
+	// all_attributes leaves out sensitive and write-only attributes
+	writeOnly, diags := getWriteOnlyAttributeIds(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if lost := lostAttributes(definitions, all, state.hiddenAttributeIds(writeOnly), mapping); len(lost) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("attribute_mapping"),
+			"Attribute Values Lost on Object Type Change",
+			fmt.Sprintf("The object is moved from object type %s to object type %s. attribute_mapping does not map the attributes %s, "+
+				"their values are lost unless they are configured for the new object type.",
+				from.Id, to.Id, strings.Join(lost, ", ")),
+		)
+	}
+}
+
+// lostAttributes returns the names of the editable attributes of the prior
+// object type that hold values and are not mapped to the new object type. The
+// values of the hidden attributes, sensitive and write-only, are not in all,
+// so these attributes are taken to hold values.
+func lostAttributes(definitions []*models.ObjectTypeAttributeScheme, all map[string][]string, hidden map[string]bool, mapping map[string]string) []string {
+	var lost []string
+	for _, definition := range definitions {
+		if definition.System || !definition.Editable || (len(all[definition.Name]) == 0 && !hidden[definition.ID]) {
+			continue
+		}
+
+		if _, ok := mapping[definition.ID]; ok {
+			continue
+		}
+
+		lost = append(lost, fmt.Sprintf("%q", definition.Name))
+	}
+
+	sort.Strings(lost)
+
+	return lost
+}
+
+// mappedAttributes returns the values of the prior object type's attributes
+// that attribute_mapping carries over to the new object type, skipping the
+// attributes configured for the new object type.
+func mappedAttributes(ctx context.Context, plan objectResourceModel, attrs []*objectAttribute, configured map[string]bool) ([]*models.ObjectPayloadAttributeScheme, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if plan.AttributeMapping.IsNull() {
+		return nil, diags
+	}
+
+	var mapping map[string]string
+	diags.Append(plan.AttributeMapping.ElementsAs(ctx, &mapping, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	fromIds := make([]string, 0, len(mapping))
+	for fromId := range mapping {
+		fromIds = append(fromIds, fromId)
+	}
+	sort.Strings(fromIds)
+
+	var attributes []*models.ObjectPayloadAttributeScheme
+	for _, fromId := range fromIds {
+		toId := mapping[fromId]
+		if configured[toId] {
+			continue
+		}
+
+		var values []*models.ObjectPayloadAttributeValueScheme
+		for _, value := range objectAttributeValues(attrs, fromId) {
+			values = append(values, &models.ObjectPayloadAttributeValueScheme{Value: value})
+		}
+
+		if len(values) > 0 {
+			attributes = append(attributes, &models.ObjectPayloadAttributeScheme{
+				ObjectTypeAttributeID: toId,
+				ObjectAttributeValues: values,
+			})
+		}
+	}
+
+	return attributes, diags
+}
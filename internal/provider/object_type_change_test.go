@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+func TestLostAttributes(t *testing.T) {
+	definitions := []*models.ObjectTypeAttributeScheme{
+		{ID: "1", Name: "Key", System: true},
+		{ID: "2", Name: "Name", Editable: true},
+		{ID: "3", Name: "Serial Number", Editable: true},
+		{ID: "4", Name: "Notes", Editable: true},
+		{ID: "5", Name: "Password", Editable: true},
+		{ID: "6", Name: "License Key", Editable: true},
+		{ID: "7", Name: "Token", Editable: true},
+	}
+
+	all := map[string][]string{
+		"Key":           {"ITSM-1"},
+		"Name":          {"My Phone"},
+		"Serial Number": {"ABC-123"},
+		"Notes":         {},
+	}
+
+	// sensitive and write-only attributes are not in all_attributes
+	hidden := map[string]bool{"5": true, "6": true, "7": true}
+
+	got := lostAttributes(definitions, all, hidden, map[string]string{"2": "20", "7": "70"})
+	if !reflect.DeepEqual(got, []string{`"License Key"`, `"Password"`, `"Serial Number"`}) {
+		t.Errorf("expected License Key, Password and Serial Number to be lost, got %v", got)
+	}
+}
@@ -0,0 +1,375 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// validateObjectAttributes checks the planned attributes of an object against
+// the attribute definitions of its object type, reporting each problem on the
+// path of the offending attribute. Unknown values are skipped, they are
+// validated again when Terraform plans the change during apply. Values of
+// sensitive and write-only attributes are never included in the diagnostics.
+func validateObjectAttributes(ctx context.Context, typeId string, definitions []*models.ObjectTypeAttributeScheme, attributes, sensitiveAttributes, references types.Set, attributeValues, attributeIds, attrValuesWo types.Map) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// the configured attribute IDs are only complete when every ID is known
+	configured := map[string]bool{}
+	complete := !attributeValues.IsUnknown() && !attrValuesWo.IsUnknown()
+
+	sets := []struct {
+		name      string
+		elements  []attr.Value
+		sensitive bool
+	}{
+		{"attributes", attributes.Elements(), false},
+		{"sensitive_attributes", sensitiveAttributes.Elements(), true},
+	}
+
+	for _, set := range sets {
+		for _, element := range set.elements {
+			object, ok := element.(types.Object)
+			if !ok || object.IsUnknown() {
+				complete = false
+				continue
+			}
+
+			var attr objectAttrResourceModel
+			if set.sensitive {
+				var sensitiveAttr objectSensitiveAttrModel
+				diags.Append(object.As(ctx, &sensitiveAttr, basetypes.ObjectAsOptions{})...)
+				attr = sensitiveAttr.attribute()
+			} else {
+				diags.Append(object.As(ctx, &attr, basetypes.ObjectAsOptions{})...)
+			}
+			if diags.HasError() {
+				return diags
+			}
+
+			if attr.AttrTypeId.IsUnknown() {
+				complete = false
+				continue
+			}
+
+			configured[attr.AttrTypeId.ValueString()] = true
+
+			attrPath := path.Root(set.name).AtSetValue(element)
+			if set.sensitive {
+				// the path of a sensitive attribute would reveal its value
+				attrPath = path.Root(set.name)
+			}
+
+			diags.Append(validateAttribute(ctx, typeId, definitions, attrPath, attr, set.sensitive)...)
+		}
+	}
+
+	if !attributeValues.IsNull() && !attributeValues.IsUnknown() && !attributeIds.IsUnknown() {
+		var names, ids map[string]string
+		diags.Append(attributeValues.ElementsAs(ctx, &names, false)...)
+		diags.Append(attributeIds.ElementsAs(ctx, &ids, false)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		for name, value := range names {
+			definition := findAttributeById(definitions, ids[name])
+			if definition == nil {
+				continue
+			}
+
+			configured[definition.ID] = true
+
+			attrPath := path.Root("attribute_values").AtMapKey(name)
+			diags.Append(validateEditable(attrPath, definition)...)
+			diags.Append(validateAttributeValue(attrPath, definition, value, false)...)
+		}
+	}
+
+	for id, element := range attrValuesWo.Elements() {
+		configured[id] = true
+		diags.Append(validateWriteOnlyAttribute(typeId, definitions, id, element)...)
+	}
+
+	for _, element := range references.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsUnknown() {
+			complete = false
+			continue
+		}
+
+		var reference objectReferenceModel
+		diags.Append(object.As(ctx, &reference, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return diags
+		}
+
+		if reference.AttrTypeId.IsUnknown() {
+			complete = false
+			continue
+		}
+
+		attrPath := path.Root("references").AtSetValue(element)
+		if configured[reference.AttrTypeId.ValueString()] {
+			diags.AddAttributeError(
+				attrPath,
+				"Duplicate Attribute",
+				fmt.Sprintf("Attribute %s is set both in references and with another attribute argument.", reference.AttrTypeId.ValueString()),
+			)
+			continue
+		}
+
+		configured[reference.AttrTypeId.ValueString()] = true
+		diags.Append(validateReference(typeId, definitions, attrPath, reference)...)
+	}
+
+	if complete {
+		diags.Append(validateMandatoryAttributes(path.Root("attributes"), typeId, definitions, configured)...)
+	}
+
+	return diags
+}
+
+// validateAttribute checks a single attribute of the attributes or sensitive_attributes set.
+func validateAttribute(ctx context.Context, typeId string, definitions []*models.ObjectTypeAttributeScheme, attrPath path.Path, attr objectAttrResourceModel, sensitive bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	definition := findAttributeById(definitions, attr.AttrTypeId.ValueString())
+	if definition == nil {
+		diags.AddAttributeError(
+			attrPath,
+			"Unknown Attribute Type",
+			fmt.Sprintf("Object type %s has no attribute with ID %s.", typeId, attr.AttrTypeId.ValueString()),
+		)
+		return diags
+	}
+
+	if attr.AttrValues.IsUnknown() {
+		return diags
+	}
+
+	diags.Append(validateEditable(attrPath, definition)...)
+
+	if err := attr.checkValueType(definition); err != nil {
+		diags.AddAttributeError(
+			attrPath,
+			"Invalid Attribute Value Type",
+			err.Error(),
+		)
+		return diags
+	}
+
+	// every value field except attr_values holds a single value
+	count := 1
+	if !attr.AttrValues.IsNull() {
+		count = len(attr.AttrValues.Elements())
+	}
+
+	diags.Append(validateCardinality(attrPath, definition, count)...)
+
+	values, d := attr.textValues(ctx)
+	diags.Append(d...)
+	for _, value := range values {
+		diags.Append(validateAttributeValue(attrPath, definition, value, sensitive)...)
+	}
+
+	return diags
+}
+
+// validateWriteOnlyAttribute checks the values of an attribute of attr_values_wo.
+func validateWriteOnlyAttribute(typeId string, definitions []*models.ObjectTypeAttributeScheme, id string, element attr.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	attrPath := path.Root("attr_values_wo").AtMapKey(id)
+
+	definition := findAttributeById(definitions, id)
+	if definition == nil {
+		diags.AddAttributeError(
+			attrPath,
+			"Unknown Attribute Type",
+			fmt.Sprintf("Object type %s has no attribute with ID %s.", typeId, id),
+		)
+		return diags
+	}
+
+	diags.Append(validateEditable(attrPath, definition)...)
+
+	list, ok := element.(types.List)
+	if !ok || list.IsNull() || list.IsUnknown() {
+		return diags
+	}
+
+	diags.Append(validateCardinality(attrPath, definition, len(list.Elements()))...)
+
+	for _, v := range list.Elements() {
+		if value, ok := v.(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+			diags.Append(validateAttributeValue(attrPath, definition, value.ValueString(), true)...)
+		}
+	}
+
+	return diags
+}
+
+// textValues returns the values of the attribute that are configured as text,
+// which are the ones that can be checked against regular expressions and
+// select options without resolving them through the API first.
+func (m objectAttrResourceModel) textValues(ctx context.Context) ([]string, diag.Diagnostics) {
+	switch m.valueField() {
+	case "attr_value":
+		if m.AttrValue.IsUnknown() {
+			return nil, nil
+		}
+		return []string{m.AttrValue.ValueString()}, nil
+	case "attr_values":
+		var values []types.String
+		diags := m.AttrValues.ElementsAs(ctx, &values, false)
+
+		var known []string
+		for _, v := range values {
+			if !v.IsUnknown() {
+				known = append(known, v.ValueString())
+			}
+		}
+		return known, diags
+	case "select_value":
+		if m.SelectValue.IsUnknown() {
+			return nil, nil
+		}
+		return []string{m.SelectValue.ValueString()}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// validateEditable reports attributes that are maintained by Assets and cannot be set.
+func validateEditable(attrPath path.Path, definition *models.ObjectTypeAttributeScheme) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if definition.System || !definition.Editable {
+		diags.AddAttributeError(
+			attrPath,
+			"Attribute Not Editable",
+			fmt.Sprintf("Attribute %q is maintained by Assets and cannot be set.", definition.Name),
+		)
+	}
+
+	return diags
+}
+
+// validateAttributeValue checks a value against the regular expression and
+// the options of the attribute.
+func validateAttributeValue(attrPath path.Path, definition *models.ObjectTypeAttributeScheme, value string, sensitive bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	quoted := fmt.Sprintf("%q", value)
+	if sensitive {
+		quoted = "(sensitive value)"
+	}
+
+	// Assets validates with Java regular expressions, expressions that Go
+	// cannot compile are left to the API
+	if definition.RegexValidation != "" {
+		if re, err := regexp.Compile("^(?:" + definition.RegexValidation + ")$"); err == nil && !re.MatchString(value) {
+			diags.AddAttributeError(
+				attrPath,
+				"Attribute Value Does Not Match",
+				fmt.Sprintf("Value %s of attribute %q does not match the regular expression %s.", quoted, definition.Name, definition.RegexValidation),
+			)
+		}
+	}
+
+	if isDefaultType(definition, defaultTypeSelect) && definition.Options != "" {
+		options := selectOptions(definition)
+		if !containsString(options, value) {
+			diags.AddAttributeError(
+				attrPath,
+				"Invalid Attribute Option",
+				fmt.Sprintf("Value %s is not an option of attribute %q. Valid options: %s.", quoted, definition.Name, strings.Join(options, ", ")),
+			)
+		}
+	}
+
+	return diags
+}
+
+// selectOptions returns the options of a Select attribute, which the API
+// returns as a comma separated list.
+func selectOptions(definition *models.ObjectTypeAttributeScheme) []string {
+	var options []string
+	for _, option := range strings.Split(definition.Options, ",") {
+		if option = strings.TrimSpace(option); option != "" {
+			options = append(options, option)
+		}
+	}
+
+	return options
+}
+
+// containsString reports whether values contains value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateMandatoryAttributes reports the mandatory attributes that are not
+// configured on the attributes of an object at attrPath.
+func validateMandatoryAttributes(attrPath path.Path, typeId string, definitions []*models.ObjectTypeAttributeScheme, configured map[string]bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var missing []string
+	for _, definition := range definitions {
+		if definition.MinimumCardinality > 0 && !definition.System && definition.Editable && !configured[definition.ID] {
+			missing = append(missing, fmt.Sprintf("%q (%s)", definition.Name, definition.ID))
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		diags.AddAttributeError(
+			attrPath,
+			"Missing Mandatory Attributes",
+			fmt.Sprintf("Object type %s requires the attributes %s.", typeId, strings.Join(missing, ", ")),
+		)
+	}
+
+	return diags
+}
+
+// validateCardinality checks the number of values against the minimum and
+// maximum cardinality of the attribute. A maximum of -1 means unlimited, the
+// API never defines a maximum of zero so that is treated as not provided.
+func validateCardinality(attrPath path.Path, definition *models.ObjectTypeAttributeScheme, count int) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if count < definition.MinimumCardinality {
+		diags.AddAttributeError(
+			attrPath,
+			"Too Few Attribute Values",
+			fmt.Sprintf("Attribute %q requires at least %d value(s), got %d.", definition.Name, definition.MinimumCardinality, count),
+		)
+	}
+
+	if definition.MaximumCardinality > 0 && count > definition.MaximumCardinality {
+		diags.AddAttributeError(
+			attrPath,
+			"Too Many Attribute Values",
+			fmt.Sprintf("Attribute %q accepts at most %d value(s), got %d.", definition.Name, definition.MaximumCardinality, count),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testAttributesSet returns an attributes set holding one attr_value per attribute type ID.
+func testAttributesSet(t *testing.T, values map[string]string) types.Set {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&objectResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	setType, _ := schemaResp.Schema.Attributes["attributes"].GetType().(types.SetType)
+	objectType, _ := setType.ElemType.(types.ObjectType)
+
+	var elements []attr.Value
+	for id, value := range values {
+		attributes := map[string]attr.Value{}
+		for name, attrType := range objectType.AttrTypes {
+			attributes[name], _ = attrType.ValueFromTerraform(ctx, tftypes.NewValue(attrType.TerraformType(ctx), nil))
+		}
+		attributes["attr_type_id"] = types.StringValue(id)
+		attributes["attr_value"] = types.StringValue(value)
+
+		elements = append(elements, types.ObjectValueMust(objectType.AttrTypes, attributes))
+	}
+
+	return types.SetValueMust(objectType, elements)
+}
+
+func TestValidateObjectAttributes(t *testing.T) {
+	definitions := []*models.ObjectTypeAttributeScheme{
+		{ID: "1", Name: "Key", System: true},
+		{ID: "2", Name: "Name", Editable: true, MinimumCardinality: 1, MaximumCardinality: 1},
+		{ID: "3", Name: "Serial Number", Editable: true, RegexValidation: "[A-Z]{3}-[0-9]+"},
+		{ID: "4", Name: "Environment", Editable: true, DefaultType: &models.ObjectTypeAssetAttributeDefaultTypeScheme{ID: defaultTypeSelect}, Options: "Production,Test"},
+	}
+
+	testCases := map[string]struct {
+		values   map[string]string
+		expected []string
+	}{
+		"valid":             {map[string]string{"2": "My Phone", "3": "ABC-123", "4": "Test"}, nil},
+		"missing mandatory": {map[string]string{"3": "ABC-123"}, []string{"Missing Mandatory Attributes"}},
+		"unknown attribute": {map[string]string{"2": "My Phone", "9": "x"}, []string{"Unknown Attribute Type"}},
+		"system attribute":  {map[string]string{"1": "ITSM-1", "2": "My Phone"}, []string{"Attribute Not Editable"}},
+		"regex mismatch":    {map[string]string{"2": "My Phone", "3": "abc"}, []string{"Attribute Value Does Not Match"}},
+		"invalid option":    {map[string]string{"2": "My Phone", "4": "Staging"}, []string{"Invalid Attribute Option"}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diags := validateObjectAttributes(context.Background(), "117", definitions, testAttributesSet(t, testCase.values), types.SetNull(types.ObjectType{}), types.SetNull(types.ObjectType{}), types.MapNull(types.StringType), types.MapNull(types.StringType), types.MapNull(types.ListType{ElemType: types.StringType}))
+
+			var summaries []string
+			for _, d := range diags {
+				summaries = append(summaries, d.Summary())
+			}
+
+			if strings.Join(summaries, ",") != strings.Join(testCase.expected, ",") {
+				t.Errorf("expected %v, got %v", testCase.expected, summaries)
+			}
+		})
+	}
+}
+
+func TestValidateWriteOnlyAttributes(t *testing.T) {
+	definitions := []*models.ObjectTypeAttributeScheme{
+		{ID: "2", Name: "Name", Editable: true, MinimumCardinality: 1, MaximumCardinality: 1},
+		{ID: "5", Name: "License Key", Editable: true, MaximumCardinality: 1, RegexValidation: "[A-Z0-9-]+"},
+	}
+
+	testCases := map[string]struct {
+		values   map[string][]string
+		expected []string
+	}{
+		"valid":             {map[string][]string{"5": {"ABCD-1234"}}, nil},
+		"unknown attribute": {map[string][]string{"9": {"x"}}, []string{"Unknown Attribute Type"}},
+		"too many values":   {map[string][]string{"5": {"A", "B"}}, []string{"Too Many Attribute Values"}},
+		"regex mismatch":    {map[string][]string{"5": {"secret value"}}, []string{"Attribute Value Does Not Match"}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			writeOnly, diags := types.MapValueFrom(context.Background(), types.ListType{ElemType: types.StringType}, testCase.values)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			diags = validateObjectAttributes(context.Background(), "117", definitions, testAttributesSet(t, map[string]string{"2": "My License"}), types.SetNull(types.ObjectType{}), types.SetNull(types.ObjectType{}), types.MapNull(types.StringType), types.MapNull(types.StringType), writeOnly)
+
+			var summaries []string
+			for _, d := range diags {
+				summaries = append(summaries, d.Summary())
+				if strings.Contains(d.Detail(), "secret") {
+					t.Errorf("diagnostic reveals the write-only value: %s", d.Detail())
+				}
+			}
+
+			if strings.Join(summaries, ",") != strings.Join(testCase.expected, ",") {
+				t.Errorf("expected %v, got %v", testCase.expected, summaries)
+			}
+		})
+	}
+}
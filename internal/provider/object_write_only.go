@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// privateWriteOnlyAttributeIds is the private state key of the IDs of the
+// attributes set with attr_values_wo. Their values never reach the state, the
+// IDs are kept so refreshes skip the attributes.
+const privateWriteOnlyAttributeIds = "write_only_attribute_ids"
+
+// privateStateReader reads the private state of a resource.
+type privateStateReader interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateStateWriter writes the private state of a resource.
+type privateStateWriter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// writeOnlyValues returns the values of attr_values_wo by attribute type ID.
+// Write-only values are only available in the configuration, the plan and
+// the state always hold null. Unknown values return nil.
+func writeOnlyValues(ctx context.Context, config tfsdk.Config) (map[string][]string, diag.Diagnostics) {
+	var attrValuesWo types.Map
+	diags := config.GetAttribute(ctx, path.Root("attr_values_wo"), &attrValuesWo)
+	if diags.HasError() || attrValuesWo.IsNull() || attrValuesWo.IsUnknown() {
+		return nil, diags
+	}
+
+	var values map[string][]string
+	diags.Append(attrValuesWo.ElementsAs(ctx, &values, false)...)
+
+	return values, diags
+}
+
+// writeOnlyAttributes returns the payload attributes of the write-only values,
+// sorted by attribute type ID for a stable payload.
+func writeOnlyAttributes(values map[string][]string) []*models.ObjectPayloadAttributeScheme {
+	ids := make([]string, 0, len(values))
+	for id := range values {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var attributes []*models.ObjectPayloadAttributeScheme
+	for _, id := range ids {
+		payloadValues := []*models.ObjectPayloadAttributeValueScheme{}
+		for _, value := range values[id] {
+			payloadValues = append(payloadValues, &models.ObjectPayloadAttributeValueScheme{Value: value})
+		}
+
+		attributes = append(attributes, &models.ObjectPayloadAttributeScheme{
+			ObjectTypeAttributeID: id,
+			ObjectAttributeValues: payloadValues,
+		})
+	}
+
+	return attributes
+}
+
+// writeOnlyAttributeIds returns the IDs of the attributes set with attr_values_wo.
+func writeOnlyAttributeIds(values map[string][]string) map[string]bool {
+	ids := map[string]bool{}
+	for id := range values {
+		ids[id] = true
+	}
+
+	return ids
+}
+
+// getWriteOnlyAttributeIds returns the IDs of the write-only attributes
+// recorded in the private state.
+func getWriteOnlyAttributeIds(ctx context.Context, private privateStateReader) (map[string]bool, diag.Diagnostics) {
+	ids := map[string]bool{}
+
+	data, diags := private.GetKey(ctx, privateWriteOnlyAttributeIds)
+	if diags.HasError() || len(data) == 0 {
+		return ids, diags
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		diags.AddError(
+			"Unable to Read Private State",
+			"The IDs of the write-only attributes could not be decoded: "+err.Error(),
+		)
+		return ids, diags
+	}
+
+	for _, id := range list {
+		ids[id] = true
+	}
+
+	return ids, diags
+}
+
+// setWriteOnlyAttributeIds records the IDs of the write-only attributes in
+// the private state, removing the key when there are none.
+func setWriteOnlyAttributeIds(ctx context.Context, private privateStateWriter, ids map[string]bool) diag.Diagnostics {
+	if len(ids) == 0 {
+		return private.SetKey(ctx, privateWriteOnlyAttributeIds, nil)
+	}
+
+	list := make([]string, 0, len(ids))
+	for id := range ids {
+		list = append(list, id)
+	}
+	sort.Strings(list)
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Unable to Write Private State",
+			"The IDs of the write-only attributes could not be encoded: "+err.Error(),
+		)
+		return diags
+	}
+
+	return private.SetKey(ctx, privateWriteOnlyAttributeIds, data)
+}
+
+// hiddenAttributeIds returns the IDs of the attributes whose values are kept
+// out of all_attributes: the sensitive and the write-only attributes.
+func (m objectResourceModel) hiddenAttributeIds(writeOnly map[string]bool) map[string]bool {
+	ids := m.sensitiveAttributeIds()
+	for id := range writeOnly {
+		ids[id] = true
+	}
+
+	return ids
+}
+
+// writeOnlyChanged reports whether the write-only values are sent with the
+// update, which is when attr_values_wo_version changes.
+func writeOnlyChanged(state, plan objectResourceModel) bool {
+	return !state.AttrValuesWoVersion.Equal(plan.AttrValuesWoVersion)
+}
@@ -0,0 +1,1022 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &objectsResource{}
+	_ resource.ResourceWithConfigure = &objectsResource{}
+
+	_ resource.ResourceWithValidateConfig = &objectsResource{}
+	_ resource.ResourceWithModifyPlan     = &objectsResource{}
+)
+
+// NewObjectsResource is a helper function to simplify the provider implementation.
+func NewObjectsResource() resource.Resource {
+	return &objectsResource{}
+}
+
+// objectsResource manages many objects of one object type as a single
+// resource, writing only the objects that changed.
+type objectsResource struct {
+	client       *assets.Client
+	workspace_id string
+	metadata     *metadataCache
+}
+
+// Metadata returns the resource type name.
+func (r *objectsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_objects"
+}
+
+type objectsResourceModel struct {
+	Id          types.String   `tfsdk:"id"`
+	WorkspaceId types.String   `tfsdk:"workspace_id"`
+	TypeId      types.String   `tfsdk:"type_id"`
+	Parallelism types.Int64    `tfsdk:"parallelism"`
+	Objects     types.Map      `tfsdk:"objects"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// objectsItemModel is a single object of a jiraassets_objects resource.
+type objectsItemModel struct {
+	Attributes types.Map    `tfsdk:"attributes"`
+	AttrValues types.Map    `tfsdk:"attr_values"`
+	Id         types.String `tfsdk:"id"`
+	ObjectKey  types.String `tfsdk:"object_key"`
+	Label      types.String `tfsdk:"label"`
+}
+
+// objectsItemAttrTypes are the attribute types of an element of objects.
+var objectsItemAttrTypes = map[string]attr.Type{
+	"attributes":  types.MapType{ElemType: types.StringType},
+	"attr_values": types.MapType{ElemType: types.ListType{ElemType: types.StringType}},
+	"id":          types.StringType,
+	"object_key":  types.StringType,
+	"label":       types.StringType,
+}
+
+// Schema defines the schema for the resource.
+func (r *objectsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages many objects of one object type. Objects are diffed by their key in objects, " +
+			"only the objects that changed are created, updated or deleted. An object that cannot be written " +
+			"is reported on its key without undoing the others, the next apply retries it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the resource, the workspace ID and object type ID separated by a slash.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workspace_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the workspace the objects belong to. Defaults to the provider workspace_id.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the object type of the objects.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parallelism": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(defaultParallelism),
+				Description: fmt.Sprintf("The number of objects written concurrently, between 1 and %d. Defaults to %d.",
+					maxParallelism, defaultParallelism),
+			},
+			"objects": schema.MapNestedAttribute{
+				Required: true,
+				Description: "The objects by a key of your choice. Changing the key of an object deletes the object " +
+					"and creates a new one.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"attributes": schema.MapAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+							Description: "The values of the attributes of the object by attribute type ID, " +
+								"in the representation the API accepts, such as the object ID of a referenced object.",
+						},
+						"attr_values": schema.MapAttribute{
+							Optional:    true,
+							ElementType: types.ListType{ElemType: types.StringType},
+							Description: "All values of the attributes with a maximum cardinality above one by attribute type ID, " +
+								"in the same representation as attributes. An attribute is set either in attributes or in attr_values.",
+						},
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the object.",
+						},
+						"object_key": schema.StringAttribute{
+							Computed:    true,
+							Description: "The key of the object.",
+						},
+						"label": schema.StringAttribute{
+							Computed:    true,
+							Description: "The label of the object.",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// ValidateConfig checks that parallelism is within its bounds.
+func (r *objectsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var parallelism types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("parallelism"), &parallelism)...)
+	if resp.Diagnostics.HasError() || parallelism.IsNull() || parallelism.IsUnknown() {
+		return
+	}
+
+	if parallelism.ValueInt64() < 1 || parallelism.ValueInt64() > maxParallelism {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parallelism"),
+			"Invalid Parallelism",
+			fmt.Sprintf("parallelism must be between 1 and %d. Got: %d", maxParallelism, parallelism.ValueInt64()),
+		)
+	}
+}
+
+// ModifyPlan validates the attributes of every object against the object type
+// and keeps the computed values of objects that exist, so only the objects
+// that change show up in the plan.
+func (r *objectsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan when the objects are destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	ctx, done, diags := withPlanTimeout(ctx, req.Plan)
+	defer done(&resp.Diagnostics)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan objectsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Objects.IsUnknown() || plan.TypeId.IsUnknown() {
+		return
+	}
+
+	prior := map[string]objectsItemModel{}
+	sameType := false
+	if !req.State.Raw.IsNull() {
+		var state objectsResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		prior, diags = state.items(ctx)
+		resp.Diagnostics.Append(diags...)
+		sameType = state.TypeId.Equal(plan.TypeId) && (plan.WorkspaceId.IsUnknown() || state.WorkspaceId.Equal(plan.WorkspaceId))
+	}
+
+	workspaceId := workspaceIdOrDefault(plan.WorkspaceId, r.workspace_id)
+
+	definitions, err := r.metadata.ObjectTypeAttributes(ctx, workspaceId, plan.TypeId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Object Type Attributes",
+			err.Error(),
+		)
+		return
+	}
+
+	planned := map[string]attr.Value{}
+	for key, element := range plan.Objects.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsUnknown() {
+			planned[key] = element
+			continue
+		}
+
+		var item objectsItemModel
+		resp.Diagnostics.Append(object.As(ctx, &item, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(validateObjectsItem(key, plan.TypeId.ValueString(), definitions, item)...)
+
+		// an existing object keeps its ID and key, its label only changes
+		// with its attributes; an object saved without ID could not be
+		// created and is created by this apply
+		if priorItem, ok := prior[key]; ok && sameType && !priorItem.Id.IsNull() {
+			item.Id = priorItem.Id
+			item.ObjectKey = priorItem.ObjectKey
+			if priorItem.Attributes.Equal(item.Attributes) && priorItem.AttrValues.Equal(item.AttrValues) {
+				item.Label = priorItem.Label
+			}
+		} else {
+			item.Id = types.StringUnknown()
+			item.ObjectKey = types.StringUnknown()
+			item.Label = types.StringUnknown()
+		}
+
+		value, diags := types.ObjectValueFrom(ctx, objectsItemAttrTypes, item)
+		resp.Diagnostics.Append(diags...)
+		planned[key] = value
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Objects, diags = types.MapValue(types.ObjectType{AttrTypes: objectsItemAttrTypes}, planned)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// validateObjectsItem checks the attributes of an object of a bulk resource
+// against the attribute definitions of the object type.
+func validateObjectsItem(key, typeId string, definitions []*models.ObjectTypeAttributeScheme, item objectsItemModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if item.Attributes.IsUnknown() || item.AttrValues.IsUnknown() {
+		return diags
+	}
+
+	attributesPath := path.Root("objects").AtMapKey(key).AtName("attributes")
+
+	configured := map[string]bool{}
+	for id, element := range item.Attributes.Elements() {
+		configured[id] = true
+
+		attrPath := attributesPath.AtMapKey(id)
+
+		definition := findAttributeById(definitions, id)
+		if definition == nil {
+			diags.AddAttributeError(
+				attrPath,
+				"Unknown Attribute Type",
+				fmt.Sprintf("Object type %s has no attribute with ID %s.", typeId, id),
+			)
+			continue
+		}
+
+		diags.Append(validateEditable(attrPath, definition)...)
+
+		if value, ok := element.(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+			diags.Append(validateAttributeValue(attrPath, definition, value.ValueString(), false)...)
+		}
+	}
+
+	attrValuesPath := path.Root("objects").AtMapKey(key).AtName("attr_values")
+
+	for id, element := range item.AttrValues.Elements() {
+		attrPath := attrValuesPath.AtMapKey(id)
+
+		if configured[id] {
+			diags.AddAttributeError(
+				attrPath,
+				"Duplicate Attribute",
+				fmt.Sprintf("Attribute %s is set both in attributes and in attr_values.", id),
+			)
+			continue
+		}
+
+		configured[id] = true
+
+		definition := findAttributeById(definitions, id)
+		if definition == nil {
+			diags.AddAttributeError(
+				attrPath,
+				"Unknown Attribute Type",
+				fmt.Sprintf("Object type %s has no attribute with ID %s.", typeId, id),
+			)
+			continue
+		}
+
+		diags.Append(validateEditable(attrPath, definition)...)
+
+		list, ok := element.(types.List)
+		if !ok || list.IsNull() || list.IsUnknown() {
+			continue
+		}
+
+		diags.Append(validateCardinality(attrPath, definition, len(list.Elements()))...)
+
+		for i, element := range list.Elements() {
+			if value, ok := element.(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+				diags.Append(validateAttributeValue(attrPath.AtListIndex(i), definition, value.ValueString(), false)...)
+			}
+		}
+	}
+
+	diags.Append(validateMandatoryAttributes(attributesPath, typeId, definitions, configured)...)
+
+	return diags
+}
+
+// items returns the objects of the resource by key.
+func (m objectsResourceModel) items(ctx context.Context) (map[string]objectsItemModel, diag.Diagnostics) {
+	items := map[string]objectsItemModel{}
+	if m.Objects.IsNull() || m.Objects.IsUnknown() {
+		return items, nil
+	}
+
+	diags := m.Objects.ElementsAs(ctx, &items, false)
+
+	return items, diags
+}
+
+// setItems sets the objects of the resource.
+func (m *objectsResourceModel) setItems(ctx context.Context, items map[string]objectsItemModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.Objects, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: objectsItemAttrTypes}, items)
+
+	return diags
+}
+
+// parallelism returns the number of objects written concurrently.
+func (m objectsResourceModel) parallelism() int {
+	if m.Parallelism.IsNull() || m.Parallelism.IsUnknown() {
+		return defaultParallelism
+	}
+
+	return int(m.Parallelism.ValueInt64())
+}
+
+// attributeValues returns the values of attributes and attr_values of the
+// object by attribute type ID.
+func (m objectsItemModel) attributeValues(ctx context.Context) (map[string]string, map[string][]string, error) {
+	single := map[string]string{}
+	if !m.Attributes.IsNull() {
+		if diags := m.Attributes.ElementsAs(ctx, &single, false); diags.HasError() {
+			return nil, nil, fmt.Errorf("reading attributes: %v", diags)
+		}
+	}
+
+	lists := map[string][]string{}
+	if !m.AttrValues.IsNull() {
+		if diags := m.AttrValues.ElementsAs(ctx, &lists, false); diags.HasError() {
+			return nil, nil, fmt.Errorf("reading attr_values: %v", diags)
+		}
+	}
+
+	return single, lists, nil
+}
+
+// values returns all values of the attributes of the object by attribute type
+// ID, a value of attributes being a single value.
+func (m objectsItemModel) values(ctx context.Context) (map[string][]string, error) {
+	single, lists, err := m.attributeValues(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string][]string, len(single)+len(lists))
+	for id, value := range single {
+		values[id] = []string{value}
+	}
+	for id, list := range lists {
+		values[id] = list
+	}
+
+	return values, nil
+}
+
+// itemPayloadAttributes returns the payload attributes of the attribute
+// values, sorted by attribute type ID for a stable payload.
+func itemPayloadAttributes(values map[string][]string) []*models.ObjectPayloadAttributeScheme {
+	ids := make([]string, 0, len(values))
+	for id := range values {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var attributes []*models.ObjectPayloadAttributeScheme
+	for _, id := range ids {
+		payloadValues := []*models.ObjectPayloadAttributeValueScheme{}
+		for _, value := range values[id] {
+			payloadValues = append(payloadValues, &models.ObjectPayloadAttributeValueScheme{Value: value})
+		}
+
+		attributes = append(attributes, &models.ObjectPayloadAttributeScheme{
+			ObjectTypeAttributeID: id,
+			ObjectAttributeValues: payloadValues,
+		})
+	}
+
+	return attributes
+}
+
+// sortedKeys returns the keys of the objects, sorted.
+func sortedKeys(items map[string]objectsItemModel) []string {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// addItemErrors reports the errors of the objects that could not be written
+// on the path of each object.
+func addItemErrors(diags *diag.Diagnostics, summary string, errs map[string]error) {
+	for _, key := range sortedErrorKeys(errs) {
+		diags.AddAttributeError(
+			path.Root("objects").AtMapKey(key),
+			summary,
+			fmt.Sprintf("Object %q: %s", key, errs[key]),
+		)
+	}
+}
+
+// addItemWarnings reports the errors of the objects that could not be written
+// as warnings on the path of each object.
+func addItemWarnings(diags *diag.Diagnostics, summary string, errs map[string]error) {
+	for _, key := range sortedErrorKeys(errs) {
+		diags.AddAttributeWarning(
+			path.Root("objects").AtMapKey(key),
+			summary,
+			fmt.Sprintf("Object %q: %s", key, errs[key]),
+		)
+	}
+}
+
+// sortedErrorKeys returns the keys of the errors, sorted.
+func sortedErrorKeys(errs map[string]error) []string {
+	keys := make([]string, 0, len(errs))
+	for key := range errs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Create creates the objects and sets the initial Terraform state.
+func (r *objectsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan objectsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, "create", createTimeout)
+	defer done(&resp.Diagnostics)
+
+	items, diags := plan.items(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workspaceId := workspaceIdOrDefault(plan.WorkspaceId, r.workspace_id)
+	typeId := plan.TypeId.ValueString()
+
+	created, errs := parallelMap(ctx, plan.parallelism(), sortedKeys(items), func(ctx context.Context, key string) (objectsItemModel, error) {
+		return r.createItem(ctx, workspaceId, typeId, items[key])
+	})
+
+	tflog.Info(ctx, "Created objects.", map[string]interface{}{
+		"created": len(created),
+		"failed":  len(errs),
+	})
+
+	if len(created) == 0 {
+		addItemErrors(&resp.Diagnostics, "Unable to Create Object", errs)
+		return
+	}
+
+	// Terraform taints a created resource on any error, so that the next
+	// apply would replace the objects that were created. The objects that
+	// failed are reported as warnings instead and saved without ID, so the
+	// next apply creates them.
+	addItemWarnings(&resp.Diagnostics, "Unable to Create Object", errs)
+	for key, item := range items {
+		if _, ok := created[key]; ok {
+			continue
+		}
+
+		item.Id = types.StringNull()
+		item.ObjectKey = types.StringNull()
+		item.Label = types.StringNull()
+		created[key] = item
+	}
+
+	plan.WorkspaceId = types.StringValue(workspaceId)
+	plan.Id = types.StringValue(workspaceId + "/" + typeId)
+	resp.Diagnostics.Append(plan.setItems(ctx, created)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the objects, dropping those deleted outside of Terraform so
+// the next apply creates them again.
+func (r *objectsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state objectsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, "read", readTimeout)
+	defer done(&resp.Diagnostics)
+
+	items, diags := state.items(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workspaceId := workspaceIdOrDefault(state.WorkspaceId, r.workspace_id)
+
+	definitions, err := r.metadata.ObjectTypeAttributes(ctx, workspaceId, state.TypeId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Object Type Attributes",
+			err.Error(),
+		)
+		return
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, key := range sortedKeys(items) {
+		if !items[key].Id.IsNull() {
+			ids = append(ids, items[key].Id.ValueString())
+		}
+	}
+
+	objects, err := searchObjectsById(ctx, r.client, workspaceId, ids)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Objects",
+			err.Error(),
+		)
+		return
+	}
+
+	refreshed := map[string]objectsItemModel{}
+	failed := map[string]error{}
+	for key, item := range items {
+		// an object without ID was never created, there is nothing to read
+		if item.Id.IsNull() {
+			refreshed[key] = item
+			continue
+		}
+
+		object, ok := objects[item.Id.ValueString()]
+		if !ok {
+			tflog.Warn(ctx, "Object no longer exists, removing it from state.", map[string]interface{}{
+				"key": key,
+				"Id":  item.Id.ValueString(),
+			})
+			continue
+		}
+
+		refreshed[key], err = readItem(ctx, definitions, item, object)
+		if err != nil {
+			// keep the prior state of objects that could not be read
+			failed[key] = err
+			refreshed[key] = item
+		}
+	}
+	addItemErrors(&resp.Diagnostics, "Unable to Read Object", failed)
+
+	state.WorkspaceId = types.StringValue(workspaceId)
+	diags = state.setItems(ctx, refreshed)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update creates, updates and deletes the objects that changed. Objects that
+// could not be written keep their prior state, so the next apply retries them.
+func (r *objectsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state objectsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, "update", updateTimeout)
+	defer done(&resp.Diagnostics)
+
+	prior, diags := state.items(ctx)
+	resp.Diagnostics.Append(diags...)
+	planned, diags := plan.items(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workspaceId := workspaceIdOrDefault(state.WorkspaceId, r.workspace_id)
+	typeId := plan.TypeId.ValueString()
+
+	// objects without ID could not be created, they are created again or,
+	// when removed from objects, dropped without a delete
+	var deleteKeys, updateKeys, createKeys []string
+	for key, item := range prior {
+		plannedItem, ok := planned[key]
+		switch {
+		case item.Id.IsNull():
+			if ok {
+				createKeys = append(createKeys, key)
+			}
+		case !ok:
+			deleteKeys = append(deleteKeys, key)
+		case !item.Attributes.Equal(plannedItem.Attributes) || !item.AttrValues.Equal(plannedItem.AttrValues):
+			updateKeys = append(updateKeys, key)
+		}
+	}
+	for key := range planned {
+		if _, ok := prior[key]; !ok {
+			createKeys = append(createKeys, key)
+		}
+	}
+
+	tflog.Info(ctx, "Updating objects.", map[string]interface{}{
+		"create": len(createKeys),
+		"update": len(updateKeys),
+		"delete": len(deleteKeys),
+	})
+
+	result := map[string]objectsItemModel{}
+	for key, item := range prior {
+		if _, ok := planned[key]; ok || !item.Id.IsNull() {
+			result[key] = item
+		}
+	}
+
+	// objects are deleted first, so objects created with the values of a
+	// deleted object do not violate unique attributes
+	deleted, errs := parallelMap(ctx, plan.parallelism(), deleteKeys, func(ctx context.Context, key string) (struct{}, error) {
+		return struct{}{}, r.deleteItem(ctx, workspaceId, prior[key])
+	})
+	for key := range deleted {
+		delete(result, key)
+	}
+	addItemErrors(&resp.Diagnostics, "Unable to Delete Object", errs)
+
+	updated, errs := parallelMap(ctx, plan.parallelism(), updateKeys, func(ctx context.Context, key string) (objectsItemModel, error) {
+		return r.updateItem(ctx, workspaceId, typeId, prior[key], planned[key])
+	})
+	for key, item := range updated {
+		result[key] = item
+	}
+	addItemErrors(&resp.Diagnostics, "Unable to Update Object", errs)
+
+	created, errs := parallelMap(ctx, plan.parallelism(), createKeys, func(ctx context.Context, key string) (objectsItemModel, error) {
+		return r.createItem(ctx, workspaceId, typeId, planned[key])
+	})
+	for key, item := range created {
+		result[key] = item
+	}
+	addItemErrors(&resp.Diagnostics, "Unable to Create Object", errs)
+
+	plan.WorkspaceId = types.StringValue(workspaceId)
+	plan.Id = state.Id
+	resp.Diagnostics.Append(plan.setItems(ctx, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the objects. Objects that could not be deleted are kept in
+// state, so the next destroy retries them.
+func (r *objectsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state objectsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, "delete", deleteTimeout)
+	defer done(&resp.Diagnostics)
+
+	items, diags := state.items(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workspaceId := workspaceIdOrDefault(state.WorkspaceId, r.workspace_id)
+
+	_, errs := parallelMap(ctx, state.parallelism(), sortedKeys(items), func(ctx context.Context, key string) (struct{}, error) {
+		return struct{}{}, r.deleteItem(ctx, workspaceId, items[key])
+	})
+	if len(errs) == 0 {
+		return
+	}
+
+	remaining := map[string]objectsItemModel{}
+	for key := range errs {
+		remaining[key] = items[key]
+	}
+
+	addItemErrors(&resp.Diagnostics, "Unable to Delete Object", errs)
+	resp.Diagnostics.Append(state.setItems(ctx, remaining)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// plannedDeletes returns the keys of the objects removed from objects, which
+// the update deletes. Objects without ID were never created and are not deleted.
+func (r *objectsResource) plannedDeletes(ctx context.Context, state tfsdk.State, plan tfsdk.Plan) ([]string, diag.Diagnostics) {
+	if state.Raw.IsNull() {
+		return nil, nil
+	}
+
+	var prior, planned types.Map
+	diags := state.GetAttribute(ctx, path.Root("objects"), &prior)
+	diags.Append(plan.GetAttribute(ctx, path.Root("objects"), &planned)...)
+	if diags.HasError() || prior.IsNull() || planned.IsUnknown() {
+		return nil, diags
+	}
+
+	var removed []string
+	for key, element := range prior.Elements() {
+		if _, ok := planned.Elements()[key]; ok {
+			continue
+		}
+
+		if object, ok := element.(types.Object); ok {
+			if id, ok := object.Attributes()["id"].(types.String); ok && id.IsNull() {
+				continue
+			}
+		}
+
+		removed = append(removed, key)
+	}
+
+	return removed, diags
+}
+
+// createItem creates an object and returns it with its computed values.
+func (r *objectsResource) createItem(ctx context.Context, workspaceId, typeId string, item objectsItemModel) (objectsItemModel, error) {
+	values, err := item.values(ctx)
+	if err != nil {
+		return item, err
+	}
+
+	payload := &models.ObjectPayloadScheme{
+		ObjectTypeID: typeId,
+		Attributes:   itemPayloadAttributes(values),
+	}
+
+	object, response, err := r.client.Object.Create(ctx, workspaceId, payload)
+	if err != nil {
+		return item, wrapAPIError(response, err)
+	}
+
+	item.Id = types.StringValue(object.ID)
+	item.ObjectKey = types.StringValue(object.ObjectKey)
+	item.Label = types.StringValue(object.Label)
+
+	return item, nil
+}
+
+// updateItem updates an object, clearing the attributes removed from its configuration.
+func (r *objectsResource) updateItem(ctx context.Context, workspaceId, typeId string, prior, planned objectsItemModel) (objectsItemModel, error) {
+	priorValues, err := prior.values(ctx)
+	if err != nil {
+		return planned, err
+	}
+
+	values, err := planned.values(ctx)
+	if err != nil {
+		return planned, err
+	}
+
+	priorIds := map[string]bool{}
+	for id := range priorValues {
+		priorIds[id] = true
+	}
+
+	plannedIds := map[string]bool{}
+	for id := range values {
+		plannedIds[id] = true
+	}
+
+	payload := &objectUpdatePayload{ObjectTypeID: typeId}
+	for _, attribute := range itemPayloadAttributes(values) {
+		payload.Attributes = append(payload.Attributes, &objectUpdateAttribute{
+			ObjectTypeAttributeID: attribute.ObjectTypeAttributeID,
+			ObjectAttributeValues: attribute.ObjectAttributeValues,
+		})
+	}
+	for _, id := range removedAttributeIds(priorIds, plannedIds) {
+		payload.Attributes = append(payload.Attributes, &objectUpdateAttribute{
+			ObjectTypeAttributeID: id,
+			ObjectAttributeValues: []*models.ObjectPayloadAttributeValueScheme{},
+		})
+	}
+
+	object, response, err := updateObject(ctx, r.client, workspaceId, prior.Id.ValueString(), payload)
+	if err != nil {
+		return planned, wrapAPIError(response, err)
+	}
+
+	planned.Id = types.StringValue(object.ID)
+	planned.ObjectKey = types.StringValue(object.ObjectKey)
+	planned.Label = types.StringValue(object.Label)
+
+	return planned, nil
+}
+
+// deleteItem deletes an object, an object that no longer exists or was never
+// created is deleted.
+func (r *objectsResource) deleteItem(ctx context.Context, workspaceId string, item objectsItemModel) error {
+	if item.Id.IsNull() {
+		return nil
+	}
+
+	response, err := r.client.Object.Delete(ctx, workspaceId, item.Id.ValueString())
+	if isNotFound(response) {
+		return nil
+	}
+
+	return wrapAPIError(response, err)
+}
+
+// readBatchSize is the number of objects read per AQL query.
+const readBatchSize = 100
+
+// searchObjectsById returns the objects with the IDs that still exist, with
+// their attributes, by ID. The objects are read in batches of readBatchSize
+// IDs to keep the AQL queries short.
+func searchObjectsById(ctx context.Context, client *assets.Client, workspaceId string, ids []string) (map[string]*searchedObject, error) {
+	objects := map[string]*searchedObject{}
+	for start := 0; start < len(ids); start += readBatchSize {
+		batch := ids[start:min(start+readBatchSize, len(ids))]
+		aql := fmt.Sprintf("objectId IN (%s)", strings.Join(batch, ", "))
+
+		for startAt := 0; ; {
+			page, response, err := searchObjects(ctx, client, workspaceId, aql, startAt, readBatchSize)
+			if err != nil {
+				return nil, wrapAPIError(response, err)
+			}
+
+			for _, object := range page.Values {
+				objects[object.ID] = object
+			}
+			startAt += len(page.Values)
+
+			if len(page.Values) == 0 || page.IsLast || (page.Total > 0 && startAt >= page.Total) {
+				break
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+// readItem returns the object with its configured attributes refreshed from
+// the object read, like objectResource refreshes attr_value and attr_values.
+// Attributes without values are dropped, so the next apply sets them again.
+func readItem(ctx context.Context, definitions []*models.ObjectTypeAttributeScheme, item objectsItemModel, object *searchedObject) (objectsItemModel, error) {
+	single, lists, err := item.attributeValues(ctx)
+	if err != nil {
+		return item, err
+	}
+
+	values := map[string]attr.Value{}
+	for id, prior := range single {
+		entries := objectAttributeEntries(object.Attributes, id)
+		if len(entries) == 0 {
+			continue
+		}
+
+		// keep the configured representation of an unchanged value
+		value := entries[0].rawValue()
+		if matchesAttributeValue(findAttributeById(definitions, id), prior, entries[0]) {
+			value = prior
+		}
+
+		values[id] = types.StringValue(value)
+	}
+
+	listValues := map[string]attr.Value{}
+	for id, prior := range lists {
+		entries := objectAttributeEntries(object.Attributes, id)
+
+		// an empty list of values is kept as configured
+		if len(entries) == 0 {
+			if len(prior) == 0 {
+				listValues[id] = item.AttrValues.Elements()[id]
+			}
+			continue
+		}
+
+		refreshed := keepValueOrder(prior, matchPriorValues(findAttributeById(definitions, id), prior, entries))
+
+		list, diags := types.ListValueFrom(ctx, types.StringType, refreshed)
+		if diags.HasError() {
+			return item, fmt.Errorf("refreshing attr_values: %v", diags)
+		}
+		listValues[id] = list
+	}
+
+	if !item.Attributes.IsNull() {
+		attributes, diags := types.MapValue(types.StringType, values)
+		if diags.HasError() {
+			return item, fmt.Errorf("refreshing attributes: %v", diags)
+		}
+		item.Attributes = attributes
+	}
+
+	if !item.AttrValues.IsNull() {
+		attrValues, diags := types.MapValue(types.ListType{ElemType: types.StringType}, listValues)
+		if diags.HasError() {
+			return item, fmt.Errorf("refreshing attr_values: %v", diags)
+		}
+		item.AttrValues = attrValues
+	}
+
+	item.Id = types.StringValue(object.ID)
+	item.ObjectKey = types.StringValue(object.ObjectKey)
+	item.Label = types.StringValue(object.Label)
+
+	return item, nil
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *objectsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(JiraAssetsProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected JiraAssetsProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerClient.client
+	r.workspace_id = providerClient.workspaceId
+	r.metadata = providerClient.metadata
+}
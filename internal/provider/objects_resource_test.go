@@ -0,0 +1,280 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccJiraAssetsObjectsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `resource "jiraassets_objects" "test" {
+					type_id = "117"
+					objects = {
+						"phone-1" = {
+							attributes = {
+								"1087" = "My Phone 1"
+							}
+						}
+						"phone-2" = {
+							attributes = {
+								"1087" = "My Phone 2"
+							}
+						}
+					}
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jiraassets_objects.test", "objects.%", "2"),
+					resource.TestCheckResourceAttr("jiraassets_objects.test", "parallelism", "4"),
+					resource.TestCheckResourceAttrSet("jiraassets_objects.test", "objects.phone-1.id"),
+					resource.TestCheckResourceAttrSet("jiraassets_objects.test", "objects.phone-2.object_key"),
+				),
+			},
+			// Update one object, delete one and create one
+			{
+				Config: `resource "jiraassets_objects" "test" {
+					type_id = "117"
+					objects = {
+						"phone-1" = {
+							attributes = {
+								"1087" = "My Renamed Phone 1"
+							}
+						}
+						"phone-3" = {
+							attributes = {
+								"1087" = "My Phone 3"
+							}
+						}
+					}
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jiraassets_objects.test", "objects.%", "2"),
+					resource.TestCheckResourceAttr("jiraassets_objects.test", "objects.phone-1.label", "My Renamed Phone 1"),
+					resource.TestCheckNoResourceAttr("jiraassets_objects.test", "objects.phone-2.id"),
+					resource.TestCheckResourceAttrSet("jiraassets_objects.test", "objects.phone-3.id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccJiraAssetsObjectsResource_invalidParallelism(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "jiraassets_objects" "test" {
+					type_id = "117"
+					parallelism = 100
+					objects = {
+						"phone-1" = {
+							attributes = {
+								"1087" = "My Phone 1"
+							}
+						}
+					}
+				}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Parallelism"),
+			},
+		},
+	})
+}
+
+func TestAccJiraAssetsObjectsResource_preventDeletes(t *testing.T) {
+	objects := `resource "jiraassets_objects" "test_prevent_deletes" {
+		type_id = "117"
+		objects = {
+			"phone-1" = {
+				attributes = {
+					"1087" = "My Phone 1"
+				}
+			}
+			"phone-2" = {
+				attributes = {
+					"1087" = "My Phone 2"
+				}
+			}
+		}
+	}`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: objects,
+			},
+			// removing an object deletes it, even though the resource is kept
+			{
+				Config: `provider "jiraassets" {
+					prevent_deletes = true
+				}
+
+				resource "jiraassets_objects" "test_prevent_deletes" {
+					type_id = "117"
+					objects = {
+						"phone-1" = {
+							attributes = {
+								"1087" = "My Phone 1"
+							}
+						}
+					}
+				}`,
+				ExpectError: regexp.MustCompile("Provider Prevents Deletes"),
+			},
+			{
+				Config: objects,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jiraassets_objects.test_prevent_deletes", "objects.%", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestSearchObjectsById(t *testing.T) {
+	var queries []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/object/aql") || r.URL.Query().Get("includeAttributes") != "true" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var body struct {
+			QlQuery string `json:"qlQuery"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		queries = append(queries, body.QlQuery+"@"+r.URL.Query().Get("startAt"))
+
+		// the first batch spans two pages, object 3 of the second batch was deleted
+		switch {
+		case strings.Contains(body.QlQuery, "(1, "):
+			if r.URL.Query().Get("startAt") == "0" {
+				_, _ = w.Write([]byte(`{"total":2,"values":[{"id":"1","objectKey":"ITSM-1","attributes":[{"objectTypeAttributeId":"1087","objectAttributeValues":[{"value":"One"}]}]}]}`))
+			} else {
+				_, _ = w.Write([]byte(`{"total":2,"values":[{"id":"2","objectKey":"ITSM-2"}]}`))
+			}
+		default:
+			_, _ = w.Write([]byte(`{"total":0,"isLast":true,"values":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := assets.New(nil, server.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make([]string, readBatchSize+1)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i + 1)
+	}
+
+	objects, err := searchObjectsById(context.Background(), client, "ws", ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(queries) != 3 || !strings.HasSuffix(queries[1], "@1") || queries[2] != fmt.Sprintf("objectId IN (%d)@0", readBatchSize+1) {
+		t.Errorf("expected two pages of the first batch and one of the second, got %q", queries)
+	}
+
+	if len(objects) != 2 || objects["1"].ObjectKey != "ITSM-1" || objects["2"].ObjectKey != "ITSM-2" {
+		t.Fatalf("expected objects 1 and 2, got %v", objects)
+	}
+
+	if entries := objectAttributeEntries(objects["1"].Attributes, "1087"); len(entries) != 1 || entries[0].rawValue() != "One" {
+		t.Errorf("expected the attributes of object 1, got %v", objects["1"].Attributes)
+	}
+}
+
+func TestObjectsReadItem(t *testing.T) {
+	ctx := context.Background()
+
+	definitions := []*models.ObjectTypeAttributeScheme{
+		{ID: "1087", Name: "Name"},
+		{ID: "1090", Name: "Tags", MaximumCardinality: -1},
+		{ID: "1091", Name: "Racks", MaximumCardinality: -1, Type: attributeTypeReference},
+		{ID: "1092", Name: "Aliases", MaximumCardinality: -1},
+		{ID: "1093", Name: "Notes", MaximumCardinality: -1},
+	}
+
+	list := func(values ...string) attr.Value {
+		elements := []attr.Value{}
+		for _, value := range values {
+			elements = append(elements, types.StringValue(value))
+		}
+		return types.ListValueMust(types.StringType, elements)
+	}
+
+	item := objectsItemModel{
+		Attributes: types.MapValueMust(types.StringType, map[string]attr.Value{"1087": types.StringValue("Phone")}),
+		AttrValues: types.MapValueMust(types.ListType{ElemType: types.StringType}, map[string]attr.Value{
+			"1090": list("b", "a"),
+			"1091": list("ITSM-10", "20"),
+			"1092": list(),
+			"1093": list("removed"),
+		}),
+		Id: types.StringValue("42"),
+	}
+
+	object := &searchedObject{
+		ID:        "42",
+		ObjectKey: "ITSM-42",
+		Label:     "Phone",
+		Attributes: []*objectAttribute{
+			{ObjectTypeAttributeId: "1087", ObjectAttributeValues: []*objectAttributeValue{{Value: "Phone"}}},
+			// returned in another order, which is not a change
+			{ObjectTypeAttributeId: "1090", ObjectAttributeValues: []*objectAttributeValue{{Value: "a"}, {Value: "b"}}},
+			// a reference configured by key keeps its key, an added reference shows up
+			{ObjectTypeAttributeId: "1091", ObjectAttributeValues: []*objectAttributeValue{
+				{ReferencedObject: &referencedObject{ID: "10", ObjectKey: "ITSM-10"}},
+				{ReferencedObject: &referencedObject{ID: "20", ObjectKey: "ITSM-20"}},
+				{ReferencedObject: &referencedObject{ID: "30", ObjectKey: "ITSM-30"}},
+			}},
+		},
+	}
+
+	refreshed, err := readItem(ctx, definitions, item, object)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !refreshed.Attributes.Equal(item.Attributes) {
+		t.Errorf("expected attributes %v, got %v", item.Attributes, refreshed.Attributes)
+	}
+
+	expected := types.MapValueMust(types.ListType{ElemType: types.StringType}, map[string]attr.Value{
+		"1090": list("b", "a"),
+		"1091": list("ITSM-10", "20", "30"),
+		"1092": list(),
+	})
+	if !refreshed.AttrValues.Equal(expected) {
+		t.Errorf("expected attr_values %v, got %v", expected, refreshed.AttrValues)
+	}
+
+	if refreshed.ObjectKey.ValueString() != "ITSM-42" {
+		t.Errorf("expected object key ITSM-42, got %v", refreshed.ObjectKey)
+	}
+}
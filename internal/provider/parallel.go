@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// defaultParallelism is the number of objects bulk resources write
+// concurrently when parallelism is not set, and maxParallelism the most they
+// may write concurrently, so a bulk resource cannot overload the Assets API.
+const (
+	defaultParallelism = 4
+	maxParallelism     = 16
+)
+
+// parallelMap calls fn for every key with at most parallelism calls running
+// concurrently, returning the results of the successful calls and the errors
+// of the failed ones by key. A failed call does not stop the others. Keys are
+// started in sorted order so runs are reproducible.
+func parallelMap[T any](ctx context.Context, parallelism int, keys []string, fn func(ctx context.Context, key string) (T, error)) (map[string]T, map[string]error) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+
+	results := map[string]T{}
+	errs := map[string]error{}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, parallelism)
+
+	for _, key := range sorted {
+		semaphore <- struct{}{}
+		wg.Add(1)
+
+		go func(key string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			result, err := fn(ctx, key)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs[key] = err
+				return
+			}
+			results[key] = result
+		}(key)
+	}
+
+	wg.Wait()
+
+	return results, errs
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelMap(t *testing.T) {
+	var running, maxRunning int32
+
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	results, errs := parallelMap(context.Background(), 3, keys, func(ctx context.Context, key string) (string, error) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+
+		if key == "c" {
+			return "", errors.New("failed")
+		}
+		return fmt.Sprintf("object %s", key), nil
+	})
+
+	if maxRunning > 3 {
+		t.Errorf("expected at most 3 concurrent calls, got %d", maxRunning)
+	}
+
+	if len(results) != 7 || results["a"] != "object a" {
+		t.Errorf("unexpected results: %v", results)
+	}
+
+	if len(errs) != 1 || errs["c"] == nil {
+		t.Errorf("expected an error for c only, got %v", errs)
+	}
+}
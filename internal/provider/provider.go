@@ -5,9 +5,11 @@ package provider
 
 import (
 	"context"
+	"net/http"
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -16,6 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/ctreminiom/go-atlassian/assets"
+	v3 "github.com/ctreminiom/go-atlassian/jira/v3"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -40,15 +43,41 @@ type JiraAssetsProvider struct {
 
 // JiraAssetsProviderModel describes the provider data model.
 type JiraAssetsProviderModel struct {
-	WorkspaceId types.String `tfsdk:"workspace_id"`
-	User        types.String `tfsdk:"user"`
-	Password    types.String `tfsdk:"password"`
+	WorkspaceId    types.String `tfsdk:"workspace_id"`
+	User           types.String `tfsdk:"user"`
+	Password       types.String `tfsdk:"password"`
+	SiteUrl        types.String `tfsdk:"site_url"`
+	HttpLogFile    types.String `tfsdk:"http_log_file"`
+	ReadOnly       types.Bool   `tfsdk:"read_only"`
+	PreventDeletes types.Bool   `tfsdk:"prevent_deletes"`
+
+	SkipCredentialsValidation types.Bool `tfsdk:"skip_credentials_validation"`
 }
 
 // JiraAssetsProviderClient describes client and worksapceId.
 type JiraAssetsProviderClient struct {
 	client      *assets.Client
 	workspaceId string
+
+	// metadata caches schemas, object types and attribute definitions for the run
+	metadata *metadataCache
+
+	// redactor masks credentials and sensitive attribute values in HTTP logs
+	redactor *redactor
+
+	// readOnly and preventDeletes are enforced for every resource by guardResource
+	readOnly       bool
+	preventDeletes bool
+}
+
+// workspaceIdOrDefault returns the workspace id configured on a resource or data
+// source, falling back to the provider's workspace id when it is not set.
+func workspaceIdOrDefault(workspaceId types.String, defaultWorkspaceId string) string {
+	if workspaceId.IsNull() || workspaceId.IsUnknown() || workspaceId.ValueString() == "" {
+		return defaultWorkspaceId
+	}
+
+	return workspaceId.ValueString()
 }
 
 func (p *JiraAssetsProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -73,6 +102,33 @@ func (p *JiraAssetsProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"site_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the Jira site, e.g. `https://example.atlassian.net`. Only required to resolve `user_email` attribute values to account IDs. " +
+					"May also be set with the `JIRAASSETS_SITE_URL` environment variable.",
+				Optional: true,
+			},
+			"http_log_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a HAR file that every Assets API request and response is written to, with credentials and sensitive values redacted. " +
+					"Useful to attach to support tickets. Every provider run, such as plan and then apply, appends to the file; delete it to start a new recording. " +
+					"Provider configurations used at the same time need separate files. May also be set with the `JIRAASSETS_HTTP_LOG_FILE` environment variable.",
+				Optional: true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, every plan that would create, update, replace or destroy a resource fails. " +
+					"Data sources and refreshes keep working, which allows plans against production with credentials that must not change Assets data.",
+				Optional: true,
+			},
+			"prevent_deletes": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, every plan that would destroy or replace a resource fails. Creates and updates are still allowed. " +
+					"Destroys that keep the data in Assets are also allowed: a `jiraassets_object` with `deletion_policy` `abandon` or `archive`, " +
+					"a `jiraassets_object_scope` and a `jiraassets_object_attribute`.",
+				Optional: true,
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, the provider does not call the Assets API during configuration to verify the credentials and workspace. " +
+					"Invalid credentials are then only reported by the first resource or data source that uses them.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -126,6 +182,8 @@ func (p *JiraAssetsProvider) Configure(ctx context.Context, req provider.Configu
 	workspaceId := os.Getenv("JIRAASSETS_WORKSPACE_ID")
 	user := os.Getenv("JIRAASSETS_USER")
 	password := os.Getenv("JIRAASSETS_PASSWORD")
+	siteUrl := os.Getenv("JIRAASSETS_SITE_URL")
+	httpLogFile := os.Getenv("JIRAASSETS_HTTP_LOG_FILE")
 
 	if !config.WorkspaceId.IsNull() {
 		workspaceId = config.WorkspaceId.ValueString()
@@ -139,6 +197,14 @@ func (p *JiraAssetsProvider) Configure(ctx context.Context, req provider.Configu
 		password = config.Password.ValueString()
 	}
 
+	if !config.SiteUrl.IsNull() {
+		siteUrl = config.SiteUrl.ValueString()
+	}
+
+	if !config.HttpLogFile.IsNull() {
+		httpLogFile = config.HttpLogFile.ValueString()
+	}
+
 	// If any of the expected configurations are missing, return errors with provider-specific guidance.
 
 	if workspaceId == "" {
@@ -182,23 +248,66 @@ func (p *JiraAssetsProvider) Configure(ctx context.Context, req provider.Configu
 
 	tflog.Debug(ctx, "Creating HashiCups client")
 
+	// log every API exchange with credentials redacted, optionally recording a HAR file
+	redactor := newRedactor()
+	redactor.Add(password)
+
+	var har *harRecorder
+	if httpLogFile != "" {
+		har = newHarRecorder(httpLogFile, p.version)
+	}
+
+	httpClient := &http.Client{
+		Transport: newLoggingTransport(http.DefaultTransport, redactor, har),
+	}
+
 	// create the Jira Assets client
-	client, err := assets.New(nil, "")
+	client, err := assets.New(httpClient, "")
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create Assets client",
 			"An unexpected error occurred when creating the Assets API client. Error: "+err.Error(),
 		)
+		return
 	}
 
 	// add authentication headers to the client, workspaceId is added to each request
 	client.Auth.SetBasicAuth(user, password)
 
+	if !config.SkipCredentialsValidation.ValueBool() {
+		resp.Diagnostics.Append(validateCredentials(ctx, client, workspaceId)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// the Jira client is only used to look up users, so it is optional
+	var jira *v3.Client
+	if siteUrl != "" {
+		jira, err = v3.New(httpClient, siteUrl)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("site_url"),
+				"Unable to create Jira client",
+				"An unexpected error occurred when creating the Jira API client. Error: "+err.Error(),
+			)
+			return
+		}
+
+		jira.Auth.SetBasicAuth(user, password)
+	}
+
 	// add workspaceId to response to be used by resources and data sources
 	providerClient := JiraAssetsProviderClient{
 		client:      client,
 		workspaceId: workspaceId,
+		metadata:    newMetadataCache(client, jira),
+		redactor:    redactor,
+
+		readOnly:       config.ReadOnly.ValueBool(),
+		preventDeletes: config.PreventDeletes.ValueBool(),
 	}
 
 	resp.DataSourceData = providerClient
@@ -207,9 +316,68 @@ func (p *JiraAssetsProvider) Configure(ctx context.Context, req provider.Configu
 	tflog.Info(ctx, "Configured Jira Assets client", map[string]any{"success": true})
 }
 
+// validateCredentials lists the object schemas of the workspace, a cheap call
+// that fails when the token is invalid, the user has no Assets access, or the
+// workspace does not exist, and maps each failure to an actionable diagnostic.
+func validateCredentials(ctx context.Context, client *assets.Client, workspaceId string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Validating Assets credentials")
+
+	// the provider configuration has no timeouts block, reads are expected to be quick
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	defer cancel()
+
+	_, response, err := client.ObjectSchema.List(ctx, workspaceId)
+	if err == nil {
+		return diags
+	}
+
+	statusCode := 0
+	if response != nil {
+		statusCode = response.Code
+	}
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		diags.AddAttributeError(
+			path.Root("password"),
+			"Invalid Assets API Token",
+			"The Assets API rejected the configured user and API token. "+
+				"Ensure the user is the email address of the account and the password is a valid, unexpired Atlassian API token for it.",
+		)
+	case http.StatusForbidden:
+		diags.AddAttributeError(
+			path.Root("user"),
+			"User Lacks Assets Access",
+			"The credentials are valid, but the user is not allowed to use Assets in workspace "+workspaceId+". "+
+				"Ensure the account has a Jira Service Management license and the Assets permissions required by your configuration.",
+		)
+	case http.StatusNotFound:
+		diags.AddAttributeError(
+			path.Root("workspace_id"),
+			"Assets Workspace Not Found",
+			"The Assets workspace "+workspaceId+" does not exist or is not accessible with these credentials. "+
+				"The workspace ID can be found at https://<your-site>.atlassian.net/rest/servicedeskapi/assets/workspace.",
+		)
+	default:
+		diags.AddError(
+			"Unable to Verify Assets Credentials",
+			"An unexpected error occurred when verifying the Assets API credentials. "+
+				"Set skip_credentials_validation = true to skip this check. Error: "+wrapAPIError(response, err).Error(),
+		)
+	}
+
+	return diags
+}
+
 func (p *JiraAssetsProvider) Resources(ctx context.Context) []func() resource.Resource {
+	// every resource is guarded so read_only and prevent_deletes apply to all of them
 	return []func() resource.Resource{
-		NewObjectResource,
+		guardResource(NewObjectResource),
+		guardResource(NewObjectsResource),
+		guardResource(NewObjectScopeResource),
+		guardResource(NewObjectAttributeResource),
 	}
 }
 
@@ -4,6 +4,12 @@
 package provider
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/assets"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
@@ -15,3 +21,46 @@ import (
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"jiraassets": providerserver.NewProtocol6WithError(New("test")()),
 }
+
+func TestValidateCredentials(t *testing.T) {
+	testCases := map[string]struct {
+		statusCode int
+		summary    string
+	}{
+		"valid":             {statusCode: http.StatusOK},
+		"invalid token":     {statusCode: http.StatusUnauthorized, summary: "Invalid Assets API Token"},
+		"no assets access":  {statusCode: http.StatusForbidden, summary: "User Lacks Assets Access"},
+		"unknown workspace": {statusCode: http.StatusNotFound, summary: "Assets Workspace Not Found"},
+		"server error":      {statusCode: http.StatusBadGateway, summary: "Unable to Verify Assets Credentials"},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(testCase.statusCode)
+				_, _ = w.Write([]byte(`{"values":[]}`))
+			}))
+			defer server.Close()
+
+			client, err := assets.New(nil, server.URL+"/")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diags := validateCredentials(context.Background(), client, "workspace")
+
+			if testCase.summary == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+
+			if len(diags) != 1 || diags[0].Summary() != testCase.summary {
+				t.Fatalf("expected a single %q diagnostic, got %v", testCase.summary, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &guardedResource{}
+	_ resource.ResourceWithConfigure        = &guardedResource{}
+	_ resource.ResourceWithModifyPlan       = &guardedResource{}
+	_ resource.ResourceWithImportState      = &guardedResource{}
+	_ resource.ResourceWithValidateConfig   = &guardedResource{}
+	_ resource.ResourceWithConfigValidators = &guardedResource{}
+	_ resource.ResourceWithUpgradeState     = &guardedResource{}
+)
+
+// resourceWithDestroyDeletes is implemented by resources whose destroy does not
+// always delete data in Assets, so prevent_deletes only blocks the destroys that do.
+type resourceWithDestroyDeletes interface {
+	// destroyDeletes returns whether destroying the resource in the given
+	// state deletes data in Assets.
+	destroyDeletes(ctx context.Context, state tfsdk.State) (bool, diag.Diagnostics)
+}
+
+// resourceWithPlannedDeletes is implemented by resources whose create or update
+// deletes data in Assets, such as objects removed from a bulk resource, so
+// prevent_deletes also blocks those plans.
+type resourceWithPlannedDeletes interface {
+	// plannedDeletes returns the keys of the objects that applying the plan to
+	// the state deletes in Assets. The state is null on create.
+	plannedDeletes(ctx context.Context, state tfsdk.State, plan tfsdk.Plan) ([]string, diag.Diagnostics)
+}
+
+// guardResource wraps a resource constructor so the resource enforces the
+// provider read_only and prevent_deletes options. Every resource returned by
+// the provider is wrapped, so resources do not implement these checks themselves.
+func guardResource(newResource func() resource.Resource) func() resource.Resource {
+	return func() resource.Resource {
+		return &guardedResource{Resource: newResource()}
+	}
+}
+
+// guardedResource blocks mutating operations on the wrapped resource at plan
+// time, and again at apply time in case a saved plan is applied after the
+// provider configuration changed. Everything else is delegated.
+type guardedResource struct {
+	resource.Resource
+
+	readOnly       bool
+	preventDeletes bool
+}
+
+func (r *guardedResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if providerClient, ok := req.ProviderData.(JiraAssetsProviderClient); ok {
+		r.readOnly = providerClient.readOnly
+		r.preventDeletes = providerClient.preventDeletes
+	}
+
+	if inner, ok := r.Resource.(resource.ResourceWithConfigure); ok {
+		inner.Configure(ctx, req, resp)
+	}
+}
+
+func (r *guardedResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if inner, ok := r.Resource.(resource.ResourceWithModifyPlan); ok {
+		inner.ModifyPlan(ctx, req, resp)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	typeName := r.typeName(ctx)
+
+	switch {
+	case req.Plan.Raw.IsNull():
+		r.checkDelete(ctx, typeName, req.State, &resp.Diagnostics)
+	case req.State.Raw.IsNull():
+		if !r.checkWrite(typeName, "create", &resp.Diagnostics) {
+			r.checkPlannedDeletes(ctx, typeName, req.State, resp.Plan, &resp.Diagnostics)
+		}
+	case len(resp.RequiresReplace) > 0 || r.schemaRequiresReplace(ctx, req, resp):
+		if !r.checkWrite(typeName, "replace", &resp.Diagnostics) {
+			r.checkDelete(ctx, typeName, req.State, &resp.Diagnostics)
+		}
+	case !resp.Plan.Raw.Equal(req.State.Raw):
+		if !r.checkWrite(typeName, "update", &resp.Diagnostics) {
+			r.checkPlannedDeletes(ctx, typeName, req.State, resp.Plan, &resp.Diagnostics)
+		}
+	}
+}
+
+func (r *guardedResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	typeName := r.typeName(ctx)
+	if r.checkWrite(typeName, "create", &resp.Diagnostics) {
+		return
+	}
+
+	state := tfsdk.State{Schema: req.Plan.Schema, Raw: tftypes.NewValue(req.Plan.Schema.Type().TerraformType(ctx), nil)}
+	if r.checkPlannedDeletes(ctx, typeName, state, req.Plan, &resp.Diagnostics) {
+		return
+	}
+
+	r.Resource.Create(ctx, req, resp)
+}
+
+func (r *guardedResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	typeName := r.typeName(ctx)
+	if r.checkWrite(typeName, "update", &resp.Diagnostics) {
+		return
+	}
+
+	if r.checkPlannedDeletes(ctx, typeName, req.State, req.Plan, &resp.Diagnostics) {
+		return
+	}
+
+	r.Resource.Update(ctx, req, resp)
+}
+
+func (r *guardedResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.checkDelete(ctx, r.typeName(ctx), req.State, &resp.Diagnostics) {
+		return
+	}
+
+	r.Resource.Delete(ctx, req, resp)
+}
+
+func (r *guardedResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	inner, ok := r.Resource.(resource.ResourceWithImportState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Resource Import Not Implemented",
+			"This resource does not support import. Please contact the provider developer for additional information.",
+		)
+		return
+	}
+
+	inner.ImportState(ctx, req, resp)
+}
+
+func (r *guardedResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if inner, ok := r.Resource.(resource.ResourceWithValidateConfig); ok {
+		inner.ValidateConfig(ctx, req, resp)
+	}
+}
+
+func (r *guardedResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	if inner, ok := r.Resource.(resource.ResourceWithConfigValidators); ok {
+		return inner.ConfigValidators(ctx)
+	}
+
+	return nil
+}
+
+func (r *guardedResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	if inner, ok := r.Resource.(resource.ResourceWithUpgradeState); ok {
+		return inner.UpgradeState(ctx)
+	}
+
+	return nil
+}
+
+// schemaRequiresReplace returns whether a RequiresReplace plan modifier of the
+// schema replaces the resource. The framework applies schema plan modifiers
+// separately and only adds their paths to the response after ModifyPlan, so
+// the modifiers of the top-level string attributes, the only ones the provider
+// marks, are run again here.
+func (r *guardedResource) schemaRequiresReplace(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) bool {
+	for name, attribute := range resp.Plan.Schema.GetAttributes() {
+		stringAttribute, ok := attribute.(schema.StringAttribute)
+		if !ok || len(stringAttribute.PlanModifiers) == 0 {
+			continue
+		}
+
+		attributePath := path.Root(name)
+
+		var configValue, stateValue, planValue types.String
+		var diags diag.Diagnostics
+		diags.Append(req.Config.GetAttribute(ctx, attributePath, &configValue)...)
+		diags.Append(req.State.GetAttribute(ctx, attributePath, &stateValue)...)
+		diags.Append(resp.Plan.GetAttribute(ctx, attributePath, &planValue)...)
+		if diags.HasError() {
+			continue
+		}
+
+		modifierReq := planmodifier.StringRequest{
+			Path:        attributePath,
+			Config:      req.Config,
+			ConfigValue: configValue,
+			State:       req.State,
+			StateValue:  stateValue,
+			Plan:        resp.Plan,
+			PlanValue:   planValue,
+			Private:     req.Private,
+		}
+
+		for _, modifier := range stringAttribute.PlanModifiers {
+			modifierResp := planmodifier.StringResponse{PlanValue: planValue}
+			modifier.PlanModifyString(ctx, modifierReq, &modifierResp)
+
+			if modifierResp.RequiresReplace {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// typeName returns the Terraform type name of the wrapped resource for diagnostics.
+func (r *guardedResource) typeName(ctx context.Context) string {
+	var resp resource.MetadataResponse
+	r.Resource.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "jiraassets"}, &resp)
+
+	return resp.TypeName
+}
+
+// checkWrite adds an error and returns true when the provider is read only.
+func (r *guardedResource) checkWrite(typeName, operation string, diags *diag.Diagnostics) bool {
+	if !r.readOnly {
+		return false
+	}
+
+	diags.AddError(
+		"Provider Is Read Only",
+		"The jiraassets provider is configured with read_only = true, so this plan cannot "+operation+" a "+typeName+" resource. "+
+			"Remove read_only from the provider configuration to allow changes to Assets data.",
+	)
+
+	return true
+}
+
+// checkDelete adds an error and returns true when the provider prevents deletes
+// and destroying the resource in the given state deletes data in Assets.
+func (r *guardedResource) checkDelete(ctx context.Context, typeName string, state tfsdk.State, diags *diag.Diagnostics) bool {
+	if r.readOnly {
+		return r.checkWrite(typeName, "destroy", diags)
+	}
+
+	if !r.preventDeletes {
+		return false
+	}
+
+	if inner, ok := r.Resource.(resourceWithDestroyDeletes); ok {
+		deletes, d := inner.destroyDeletes(ctx, state)
+		diags.Append(d...)
+		if diags.HasError() {
+			return true
+		}
+
+		if !deletes {
+			return false
+		}
+	}
+
+	diags.AddError(
+		"Provider Prevents Deletes",
+		"The jiraassets provider is configured with prevent_deletes = true, so this plan cannot destroy a "+typeName+" resource. "+
+			"Remove the resource from the state with terraform state rm instead, or remove prevent_deletes from the provider configuration.",
+	)
+
+	return true
+}
+
+// checkPlannedDeletes adds an error and returns true when the provider prevents
+// deletes and applying the plan deletes objects in Assets.
+func (r *guardedResource) checkPlannedDeletes(ctx context.Context, typeName string, state tfsdk.State, plan tfsdk.Plan, diags *diag.Diagnostics) bool {
+	if !r.preventDeletes {
+		return false
+	}
+
+	inner, ok := r.Resource.(resourceWithPlannedDeletes)
+	if !ok {
+		return false
+	}
+
+	keys, d := inner.plannedDeletes(ctx, state, plan)
+	diags.Append(d...)
+	if diags.HasError() {
+		return true
+	}
+
+	if len(keys) == 0 {
+		return false
+	}
+
+	sort.Strings(keys)
+	diags.AddError(
+		"Provider Prevents Deletes",
+		fmt.Sprintf("The jiraassets provider is configured with prevent_deletes = true, so this plan cannot delete the objects %s "+
+			"of a %s resource. Change the configuration so the objects are kept, or remove prevent_deletes from the provider configuration.",
+			summarizeKeys(keys), typeName),
+	)
+
+	return true
+}
@@ -0,0 +1,401 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccJiraAssetsProviderReadOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `provider "jiraassets" {
+					read_only = true
+				}
+
+				resource "jiraassets_object" "test" {
+					type_id = "117"
+					attributes = [
+						{
+							attr_type_id = "1087"
+							attr_value = "My Phone"
+						}
+					]
+				}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Provider Is Read Only"),
+			},
+		},
+	})
+}
+
+// guardTestResource is a resource that records the operations that reach it.
+// When deletes is set, destroying it deletes data only if deletes is true.
+type guardTestResource struct {
+	deletes *bool
+	called  []string
+}
+
+func (r *guardTestResource) Metadata(ctx context.Context, req fwresource.MetadataRequest, resp *fwresource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_test"
+}
+
+func (r *guardTestResource) Schema(ctx context.Context, req fwresource.SchemaRequest, resp *fwresource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{Optional: true},
+			"key": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *guardTestResource) Create(ctx context.Context, req fwresource.CreateRequest, resp *fwresource.CreateResponse) {
+	r.called = append(r.called, "create")
+}
+
+func (r *guardTestResource) Read(ctx context.Context, req fwresource.ReadRequest, resp *fwresource.ReadResponse) {
+	r.called = append(r.called, "read")
+}
+
+func (r *guardTestResource) Update(ctx context.Context, req fwresource.UpdateRequest, resp *fwresource.UpdateResponse) {
+	r.called = append(r.called, "update")
+}
+
+func (r *guardTestResource) Delete(ctx context.Context, req fwresource.DeleteRequest, resp *fwresource.DeleteResponse) {
+	r.called = append(r.called, "delete")
+}
+
+// guardTestDestroyResource also reports whether its destroy deletes data.
+type guardTestDestroyResource struct {
+	guardTestResource
+}
+
+func (r *guardTestDestroyResource) destroyDeletes(ctx context.Context, state tfsdk.State) (bool, diag.Diagnostics) {
+	return *r.deletes, nil
+}
+
+// guardTestValue returns a value of the test resource schema, null without a name.
+func guardTestValue(name *string, key string) tftypes.Value {
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"name": tftypes.String, "key": tftypes.String}}
+	if name == nil {
+		return tftypes.NewValue(objectType, nil)
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, *name),
+		"key":  tftypes.NewValue(tftypes.String, key),
+	})
+}
+
+func TestGuardedResourceModifyPlan(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	(&guardTestResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	before, after := "before", "after"
+
+	// replace changes key, which the schema marks as requiring replacement;
+	// replace-in-plan is replaced by the wrapped resource ModifyPlan instead.
+	operations := map[string]struct {
+		state, plan *string
+		planKey     string
+		replace     bool
+	}{
+		"create":          {nil, &after, "a", false},
+		"update":          {&before, &after, "a", false},
+		"replace":         {&before, &before, "b", false},
+		"replace-in-plan": {&before, &after, "a", true},
+		"delete":          {&before, nil, "a", false},
+		"no-op":           {&before, &before, "a", false},
+	}
+
+	testCases := []struct {
+		operation      string
+		readOnly       bool
+		preventDeletes bool
+		expected       string
+	}{
+		{"create", false, false, ""},
+		{"update", false, false, ""},
+		{"replace", false, false, ""},
+		{"delete", false, false, ""},
+		{"create", true, false, "Provider Is Read Only"},
+		{"update", true, false, "Provider Is Read Only"},
+		{"replace", true, false, "Provider Is Read Only"},
+		{"delete", true, false, "Provider Is Read Only"},
+		{"no-op", true, false, ""},
+		{"create", false, true, ""},
+		{"update", false, true, ""},
+		{"replace", false, true, "Provider Prevents Deletes"},
+		{"replace-in-plan", false, false, ""},
+		{"replace-in-plan", true, false, "Provider Is Read Only"},
+		{"replace-in-plan", false, true, "Provider Prevents Deletes"},
+		{"delete", false, true, "Provider Prevents Deletes"},
+		{"replace", true, true, "Provider Is Read Only"},
+		{"delete", true, true, "Provider Is Read Only"},
+	}
+
+	for _, testCase := range testCases {
+		name := testCase.operation
+		if testCase.readOnly {
+			name += "/read_only"
+		}
+		if testCase.preventDeletes {
+			name += "/prevent_deletes"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			operation := operations[testCase.operation]
+			r := &guardedResource{Resource: &guardTestResource{}, readOnly: testCase.readOnly, preventDeletes: testCase.preventDeletes}
+
+			// the framework runs ModifyPlan with an empty RequiresReplace and
+			// adds the paths of the schema plan modifiers afterwards
+			req := fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: guardTestValue(operation.plan, operation.planKey)},
+				State:  tfsdk.State{Schema: schemaResp.Schema, Raw: guardTestValue(operation.state, "a")},
+				Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: guardTestValue(operation.plan, operation.planKey)},
+			}
+			resp := fwresource.ModifyPlanResponse{Plan: req.Plan, RequiresReplace: path.Paths{}}
+			if operation.replace {
+				resp.RequiresReplace = path.Paths{path.Root("name")}
+			}
+
+			r.ModifyPlan(ctx, req, &resp)
+
+			var summaries []string
+			for _, d := range resp.Diagnostics {
+				summaries = append(summaries, d.Summary())
+			}
+
+			if strings.Join(summaries, ",") != testCase.expected {
+				t.Errorf("expected %q, got %v", testCase.expected, summaries)
+			}
+		})
+	}
+}
+
+func TestGuardedResourceDelete(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	(&guardTestResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	deletes, keeps := true, false
+	name := "test"
+
+	testCases := map[string]struct {
+		readOnly       bool
+		preventDeletes bool
+		deletes        *bool
+		expected       string
+	}{
+		"allowed":                     {false, false, nil, ""},
+		"read_only":                   {true, false, nil, "Provider Is Read Only"},
+		"prevent_deletes":             {false, true, nil, "Provider Prevents Deletes"},
+		"prevent_deletes, deletes":    {false, true, &deletes, "Provider Prevents Deletes"},
+		"prevent_deletes, keeps data": {false, true, &keeps, ""},
+		"read_only, keeps data":       {true, false, &keeps, "Provider Is Read Only"},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			inner := &guardTestDestroyResource{guardTestResource{deletes: testCase.deletes}}
+
+			r := &guardedResource{Resource: &inner.guardTestResource, readOnly: testCase.readOnly, preventDeletes: testCase.preventDeletes}
+			if testCase.deletes != nil {
+				r.Resource = inner
+			}
+
+			req := fwresource.DeleteRequest{State: tfsdk.State{Schema: schemaResp.Schema, Raw: guardTestValue(&name, "a")}}
+			var resp fwresource.DeleteResponse
+
+			r.Delete(ctx, req, &resp)
+
+			var summaries []string
+			for _, d := range resp.Diagnostics {
+				summaries = append(summaries, d.Summary())
+			}
+
+			if strings.Join(summaries, ",") != testCase.expected {
+				t.Errorf("expected %q, got %v", testCase.expected, summaries)
+			}
+
+			if called := strings.Join(inner.called, ","); (testCase.expected == "") != (called == "delete") {
+				t.Errorf("unexpected calls to the wrapped resource: %q", called)
+			}
+		})
+	}
+}
+
+// guardTestPlannedResource deletes the objects in deletes when it is created or updated.
+type guardTestPlannedResource struct {
+	guardTestResource
+	deletes []string
+}
+
+func (r *guardTestPlannedResource) plannedDeletes(ctx context.Context, state tfsdk.State, plan tfsdk.Plan) ([]string, diag.Diagnostics) {
+	return r.deletes, nil
+}
+
+func TestGuardedResourcePlannedDeletes(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	(&guardTestResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	before, after := "before", "after"
+
+	testCases := map[string]struct {
+		preventDeletes bool
+		deletes        []string
+		expected       string
+	}{
+		"no deletes":                  {true, nil, ""},
+		"deletes":                     {false, []string{"b", "a"}, ""},
+		"prevent_deletes":             {true, []string{"b", "a"}, "Provider Prevents Deletes"},
+		"prevent_deletes, no deletes": {true, []string{}, ""},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			inner := &guardTestPlannedResource{deletes: testCase.deletes}
+			r := &guardedResource{Resource: inner, preventDeletes: testCase.preventDeletes}
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: guardTestValue(&before, "a")}
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: guardTestValue(&after, "a")}
+
+			planReq := fwresource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw}, State: state, Plan: plan}
+			planResp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, planReq, &planResp)
+
+			// a saved plan is checked again when it is applied
+			var updateResp fwresource.UpdateResponse
+			r.Update(ctx, fwresource.UpdateRequest{State: state, Plan: plan}, &updateResp)
+
+			for _, diags := range []diag.Diagnostics{planResp.Diagnostics, updateResp.Diagnostics} {
+				var summaries []string
+				for _, d := range diags {
+					summaries = append(summaries, d.Summary())
+				}
+
+				if strings.Join(summaries, ",") != testCase.expected {
+					t.Errorf("expected %q, got %v", testCase.expected, summaries)
+				}
+			}
+
+			if called := strings.Join(inner.called, ","); (testCase.expected == "") != (called == "update") {
+				t.Errorf("unexpected calls to the wrapped resource: %q", called)
+			}
+		})
+	}
+}
+
+func TestObjectsPlannedDeletes(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	(&objectsResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	value := func(keys ...string) tftypes.Value {
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+		items := map[string]objectsItemModel{}
+		for _, key := range keys {
+			items[key] = objectsItemModel{
+				Attributes: types.MapValueMust(types.StringType, map[string]attr.Value{"1087": types.StringValue(key)}),
+				AttrValues: types.MapNull(types.ListType{ElemType: types.StringType}),
+				Id:         types.StringValue("id-" + key),
+				ObjectKey:  types.StringValue("ITSM-" + key),
+				Label:      types.StringValue(key),
+			}
+
+			// an object that could not be created is saved without ID
+			if strings.HasPrefix(key, "failed") {
+				item := items[key]
+				item.Id = types.StringNull()
+				item.ObjectKey = types.StringNull()
+				item.Label = types.StringNull()
+				items[key] = item
+			}
+		}
+
+		objects, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: objectsItemAttrTypes}, items)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		if diags := state.SetAttribute(ctx, path.Root("objects"), objects); diags.HasError() {
+			t.Fatal(diags)
+		}
+
+		return state.Raw
+	}
+
+	deletes, diags := (&objectsResource{}).plannedDeletes(ctx,
+		tfsdk.State{Schema: schemaResp.Schema, Raw: value("a", "b", "c", "failed")},
+		tfsdk.Plan{Schema: schemaResp.Schema, Raw: value("a", "d")})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	sort.Strings(deletes)
+	if strings.Join(deletes, ",") != "b,c" {
+		t.Errorf("expected b,c to be deleted, got %v", deletes)
+	}
+}
+
+func TestObjectDestroyDeletes(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	(&objectResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	testCases := map[string]struct {
+		deletionPolicy types.String
+		expected       bool
+	}{
+		"unset":   {types.StringNull(), true},
+		"delete":  {types.StringValue(deletionPolicyDelete), true},
+		"archive": {types.StringValue(deletionPolicyArchive), false},
+		"abandon": {types.StringValue(deletionPolicyAbandon), false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			if diags := state.SetAttribute(ctx, path.Root("deletion_policy"), testCase.deletionPolicy); diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			deletes, diags := (&objectResource{}).destroyDeletes(ctx, state)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			if deletes != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, deletes)
+			}
+		})
+	}
+}
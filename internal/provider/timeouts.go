@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// Default operation timeouts, used when the timeouts block does not set one.
+// Creating objects can take minutes while Assets processes a large import
+// into the same schema, reads are expected to be quick.
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
+
+// timeoutsBlock returns the timeouts block every resource of the provider
+// supports, configuring the create, read, update and delete timeouts.
+func timeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create: true,
+		Read:   true,
+		Update: true,
+		Delete: true,
+	})
+}
+
+// withTimeout returns a context that is cancelled after the timeout, and a
+// function that reports the timeout in diags if the operation failed because
+// the deadline passed. The function must be deferred by the operation:
+//
+//	ctx, done := withTimeout(ctx, "create", timeout)
+//	defer done(&resp.Diagnostics)
+func withTimeout(ctx context.Context, operation string, timeout time.Duration) (context.Context, func(diags *diag.Diagnostics)) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	return ctx, func(diags *diag.Diagnostics) {
+		defer cancel()
+
+		if diags.HasError() && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			diags.Append(timeoutDiagnostic(operation, timeout))
+		}
+	}
+}
+
+// withPlanTimeout is withTimeout for the API calls made while planning, which
+// are bounded by the read timeout of the planned resource.
+func withPlanTimeout(ctx context.Context, plan tfsdk.Plan) (context.Context, func(diags *diag.Diagnostics), diag.Diagnostics) {
+	var value timeouts.Value
+	diags := plan.GetAttribute(ctx, path.Root("timeouts"), &value)
+
+	readTimeout, d := value.Read(ctx, defaultReadTimeout)
+	diags.Append(d...)
+
+	ctx, done := withTimeout(ctx, "read", readTimeout)
+
+	return ctx, done, diags
+}
+
+// timeoutDiagnostic returns the error reported when an operation does not
+// complete within its timeout.
+func timeoutDiagnostic(operation string, timeout time.Duration) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Operation Timed Out",
+		fmt.Sprintf("The %s operation did not complete within %s. Assets may be busy, for example with a large import. "+
+			"Increase timeouts.%s in the resource configuration to wait longer.", operation, timeout, operation),
+	)
+}
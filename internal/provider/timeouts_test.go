@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestWithTimeout(t *testing.T) {
+	testCases := map[string]struct {
+		timeout  time.Duration
+		failed   bool
+		expected int
+	}{
+		"completed":          {time.Minute, false, 0},
+		"failed":             {time.Minute, true, 1},
+		"timed out":          {time.Nanosecond, true, 2},
+		"deadline succeeded": {time.Nanosecond, false, 0},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			ctx, done := withTimeout(context.Background(), "create", testCase.timeout)
+			<-time.After(time.Millisecond)
+			if testCase.failed {
+				diags.AddError("Error during object creation", "request failed")
+			}
+			done(&diags)
+
+			if len(diags) != testCase.expected {
+				t.Errorf("expected %d diagnostics, got %d: %v", testCase.expected, len(diags), diags)
+			}
+
+			if ctx.Err() == nil {
+				t.Error("expected the context to be cancelled once the operation is done")
+			}
+		})
+	}
+}